@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// toolAdoption is when a tool showed up in (or vanished from) the history
+type ToolAdoption struct {
+	Command   string
+	FirstSeen time.Time
+	LastSeen  time.Time
+	Count     int
+}
+
+// adoptionMonth lists the tools first used in a month (2006-01)
+type AdoptionMonth struct {
+	Month string
+	Tools []string
+}
+
+const (
+	// tools seen this early were already in use when the history starts
+	adoptionWarmup = 30 * 24 * time.Hour
+	// unused for this long before the history ends counts as dropped
+	abandonAfter     = 60 * 24 * time.Hour
+	minAdoptionUses  = 5
+	topAdoptionCount = 8
+	discoveryWindow  = 365 * 24 * time.Hour
+)
+
+// adoptionTracker keeps first/last use of every base command
+type adoptionTracker struct {
+	tools  map[string]*ToolAdoption
+	counts *topCounts[string] // base command -> uses, filled by the analyzer
+	pruned bool               // rarely used commands were forgotten
+}
+
+func newAdoptionTracker(counts *topCounts[string]) *adoptionTracker {
+	return &adoptionTracker{tools: make(map[string]*ToolAdoption), counts: counts}
+}
+
+func (a *adoptionTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	if !cmd.HasTime {
+		return
+	}
+	tool := a.tools[baseCmd]
+	if tool == nil {
+		if len(a.tools) >= maxTrackedKeys {
+			a.prune()
+		}
+		tool = &ToolAdoption{Command: baseCmd, FirstSeen: cmd.Timestamp, LastSeen: cmd.Timestamp}
+		a.tools[baseCmd] = tool
+	}
+	if cmd.Timestamp.Before(tool.FirstSeen) {
+		tool.FirstSeen = cmd.Timestamp
+	}
+	if cmd.Timestamp.After(tool.LastSeen) {
+		tool.LastSeen = cmd.Timestamp
+	}
+	tool.Count++
+}
+
+// prune forgets the least used commands, so a history of one-off scripts
+// can't grow the map forever. tools worth reporting are used far more
+func (a *adoptionTracker) prune() {
+	a.pruned = true
+	counts := make([]int, 0, len(a.tools))
+	for _, tool := range a.tools {
+		counts = append(counts, tool.Count)
+	}
+	floor := pruneFloor(counts, maxTrackedKeys/2)
+	for name, tool := range a.tools {
+		if tool.Count <= floor {
+			delete(a.tools, name)
+		}
+	}
+}
+
+func (a *adoptionTracker) Finish(stats *Stats) {
+	if a.pruned {
+		stats.Approximate = true
+	}
+	commandCounts := a.counts.snapshot(stats)
+	if len(a.tools) == 0 {
+		return
+	}
+	start, end := stats.FirstCommand, stats.LastCommand
+
+	timeline := make(map[string][]string)
+	for _, tool := range a.tools {
+		if tool.Count < minAdoptionUses || !isTool(tool.Command, commandCounts) {
+			continue
+		}
+
+		if tool.FirstSeen.Sub(start) > adoptionWarmup {
+			stats.NewTools = append(stats.NewTools, *tool)
+			month := tool.FirstSeen.Format("2006-01")
+			timeline[month] = append(timeline[month], tool.Command)
+			if end.Sub(tool.FirstSeen) <= discoveryWindow && end.Sub(tool.LastSeen) <= abandonAfter {
+				stats.Discoveries = append(stats.Discoveries, *tool)
+			}
+		}
+		if end.Sub(tool.LastSeen) > abandonAfter {
+			stats.AbandonedTools = append(stats.AbandonedTools, *tool)
+		}
+	}
+
+	// newest adoptions and drops first, most used discoveries first
+	sort.Slice(stats.NewTools, func(i, j int) bool {
+		return adoptedBefore(stats.NewTools[j], stats.NewTools[i])
+	})
+	sort.Slice(stats.AbandonedTools, func(i, j int) bool {
+		a, b := stats.AbandonedTools[i], stats.AbandonedTools[j]
+		if !a.LastSeen.Equal(b.LastSeen) {
+			return a.LastSeen.After(b.LastSeen)
+		}
+		return a.Command < b.Command
+	})
+	sort.Slice(stats.Discoveries, func(i, j int) bool {
+		a, b := stats.Discoveries[i], stats.Discoveries[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Command < b.Command
+	})
+	for _, list := range []*[]ToolAdoption{&stats.NewTools, &stats.AbandonedTools, &stats.Discoveries} {
+		if len(*list) > topAdoptionCount {
+			*list = (*list)[:topAdoptionCount]
+		}
+	}
+
+	for month, tools := range timeline {
+		sort.Slice(tools, func(i, j int) bool {
+			return commandCounts[tools[i]] > commandCounts[tools[j]] ||
+				(commandCounts[tools[i]] == commandCounts[tools[j]] && tools[i] < tools[j])
+		})
+		stats.AdoptionTimeline = append(stats.AdoptionTimeline, AdoptionMonth{Month: month, Tools: tools})
+	}
+	sort.Slice(stats.AdoptionTimeline, func(i, j int) bool {
+		return stats.AdoptionTimeline[i].Month < stats.AdoptionTimeline[j].Month
+	})
+}
+
+func adoptedBefore(a, b ToolAdoption) bool {
+	if !a.FirstSeen.Equal(b.FirstSeen) {
+		return a.FirstSeen.Before(b.FirstSeen)
+	}
+	return a.Command > b.Command
+}
+
+// isTool filters out scripts, paths, assignments and mistyped commands
+func isTool(cmd string, commandCounts map[string]int) bool {
+	if strings.ContainsAny(cmd, "/=$.~") {
+		return false
+	}
+	if knownTools[cmd] {
+		return true
+	}
+	return !(plausibleTypo(cmd) && closestKnownTool(cmd, commandCounts) != "")
+}
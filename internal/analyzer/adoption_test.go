@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+// usedOn repeats a command on consecutive days from a date in 2025
+func usedOn(command string, month time.Month, day, times int) []string {
+	var lines []string
+	for i := range times {
+		lines = append(lines, timed(time.Date(2025, month, day+i, 10, 0, 0, 0, time.UTC), command))
+	}
+	return lines
+}
+
+func TestAdoption(t *testing.T) {
+	var lines []string
+	lines = append(lines, usedOn("git status", 1, 1, 5)...)
+	// dropped in March
+	lines = append(lines, usedOn("ack foo", 1, 2, 5)...)
+	// adopted in June and still in use
+	lines = append(lines, usedOn("rg foo", 6, 1, 10)...)
+	// adopted in June, too rarely used to count
+	lines = append(lines, usedOn("fd bar", 6, 1, 2)...)
+	// scripts and typos are not tools
+	lines = append(lines, usedOn("./deploy.sh", 6, 1, 5)...)
+	lines = append(lines, usedOn("gti status", 6, 1, 5)...)
+	lines = append(lines, usedOn("git status", 12, 1, 10)...)
+	lines = append(lines, usedOn("rg foo", 12, 1, 5)...)
+	stats := analyzeLines(t, lines...)
+
+	if len(stats.NewTools) != 1 || stats.NewTools[0].Command != "rg" {
+		t.Fatalf("NewTools = %+v, want rg", stats.NewTools)
+	}
+	rg := stats.NewTools[0]
+	if rg.Count != 15 || !rg.FirstSeen.Equal(time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)) {
+		t.Errorf("rg = %+v, want 15 uses from 2025-06-01", rg)
+	}
+	if len(stats.Discoveries) != 1 || stats.Discoveries[0].Command != "rg" {
+		t.Errorf("Discoveries = %+v, want rg", stats.Discoveries)
+	}
+	if len(stats.AbandonedTools) != 1 || stats.AbandonedTools[0].Command != "ack" {
+		t.Errorf("AbandonedTools = %+v, want ack", stats.AbandonedTools)
+	}
+	if len(stats.AdoptionTimeline) != 1 || stats.AdoptionTimeline[0].Month != "2025-06" ||
+		len(stats.AdoptionTimeline[0].Tools) != 1 || stats.AdoptionTimeline[0].Tools[0] != "rg" {
+		t.Errorf("AdoptionTimeline = %+v, want rg in 2025-06", stats.AdoptionTimeline)
+	}
+}
+
+func TestAdoptionNeedsTimestamps(t *testing.T) {
+	stats := analyzeLines(t, "rg foo", "rg foo", "rg foo", "rg foo", "rg foo")
+	if len(stats.NewTools)+len(stats.AbandonedTools)+len(stats.AdoptionTimeline) != 0 {
+		t.Errorf("adoption without timestamps: %+v %+v", stats.NewTools, stats.AbandonedTools)
+	}
+}
+
+func TestIsTool(t *testing.T) {
+	counts := map[string]int{"git": 100, "gti": 3, "deploy": 5}
+	tests := []struct {
+		cmd  string
+		want bool
+	}{
+		{"git", true},
+		{"deploy", true},
+		{"gti", false},
+		{"./run", false},
+		{"FOO=1", false},
+		{"~/bin/x", false},
+	}
+	for _, tt := range tests {
+		if got := isTool(tt.cmd, counts); got != tt.want {
+			t.Errorf("isTool(%q) = %v, want %v", tt.cmd, got, tt.want)
+		}
+	}
+}
@@ -46,6 +46,49 @@ var archetypes = []struct {
 			return s.CategoryPct["Containers"] * 2.5
 		},
 	},
+	{
+		Name:    "THE KUBE WRANGLER",
+		Icon:    "{k}",
+		Tagline: "kubectl apply and pray",
+		Detect: func(s *Stats) float64 {
+			kubePct := float64(s.Infra.KubeCommands) / float64(s.TotalCommands) * 100
+			// juggling clusters is the real job
+			if len(s.Infra.TopContexts) > 1 {
+				return kubePct * 3
+			}
+			return kubePct * 2
+		},
+	},
+	{
+		Name:    "THE TERRAFORMER",
+		Icon:    "[tf]",
+		Tagline: "Infrastructure is just code",
+		Detect: func(s *Stats) float64 {
+			runs := s.Infra.TerraformPlans + s.Infra.TerraformApplies
+			return float64(runs) / float64(s.TotalCommands) * 100 * 4
+		},
+	},
+	{
+		Name:    "THE CLOUD HOPPER",
+		Icon:    "(~)",
+		Tagline: "Multi-cloud, multi-problem",
+		Detect: func(s *Stats) float64 {
+			return s.CategoryPct["Cloud"] * float64(1+len(s.Infra.Providers))
+		},
+	},
+	{
+		Name:    "THE CHAOS ENGINEER",
+		Icon:    "!!!",
+		Tagline: "Testing in production since forever",
+		Detect: func(s *Stats) float64 {
+			// applying without planning first
+			yolo := s.Infra.TerraformApplies - s.Infra.TerraformPlans + s.Infra.TerraformDestroys
+			if yolo <= 0 {
+				return 0
+			}
+			return float64(yolo) / float64(s.TotalCommands) * 100 * 5
+		},
+	},
 	{
 		Name:    "THE PACKAGE GOBLIN",
 		Icon:    "[+]",
@@ -78,7 +121,11 @@ var archetypes = []struct {
 		Icon:    "~>~",
 		Tagline: "My servers miss me",
 		Detect: func(s *Stats) float64 {
-			return s.CategoryPct["Network"] * 2
+			remote := s.SSH.Sessions + s.SSH.Transfers
+			remotePct := float64(remote) / float64(s.TotalCommands) * 100
+			// many hosts make a true nomad, up to double the score
+			hosts := float64(min(s.SSH.DistinctHosts, 10))
+			return remotePct * 2.5 * (1 + hosts/10)
 		},
 	},
 	{
@@ -0,0 +1,132 @@
+package analyzer
+
+import "slices"
+
+// maxTrackedKeys bounds the counters keyed by free-form text (command lines,
+// file names, command sequences) so a history of unique lines can't grow
+// them without limit
+const maxTrackedKeys = 1 << 14
+
+// topCounts counts keys with the space-saving algorithm: it tracks at most
+// limit keys, and a new key takes the place of the least counted one.
+// counts are exact until more than limit distinct keys have been seen.
+// after n adds, any key seen more than n/limit times is still tracked and
+// every reported count is low by at most n/limit, never high
+type topCounts[K comparable] struct {
+	limit   int
+	index   map[K]int       // key -> position in entries
+	entries []countEntry[K] // min-heap on count
+	evicted bool
+}
+
+// countEntry is a tracked key. the key was seen between count-err and
+// count times
+type countEntry[K comparable] struct {
+	key   K
+	count int
+	err   int
+}
+
+func newTopCounts[K comparable](limit int) *topCounts[K] {
+	return &topCounts[K]{limit: limit, index: make(map[K]int)}
+}
+
+// add counts one use of key
+func (c *topCounts[K]) add(key K) {
+	if i, ok := c.index[key]; ok {
+		c.entries[i].count++
+		c.down(i)
+		return
+	}
+	if len(c.entries) < c.limit {
+		c.entries = append(c.entries, countEntry[K]{key: key, count: 1})
+		c.index[key] = len(c.entries) - 1
+		c.up(len(c.entries) - 1)
+		return
+	}
+
+	// the newcomer may have been evicted before, at most min times
+	least := c.entries[0]
+	delete(c.index, least.key)
+	c.entries[0] = countEntry[K]{key: key, count: least.count + 1, err: least.count}
+	c.index[key] = 0
+	c.down(0)
+	c.evicted = true
+}
+
+// count returns how often key was at least seen, 0 if it isn't tracked
+func (c *topCounts[K]) count(key K) int {
+	i, ok := c.index[key]
+	if !ok {
+		return 0
+	}
+	return c.entries[i].count - c.entries[i].err
+}
+
+// distinct returns the number of tracked keys: every key seen, unless
+// some were evicted
+func (c *topCounts[K]) distinct() int {
+	return len(c.entries)
+}
+
+// snapshot returns the guaranteed count of every tracked key, and marks
+// stats approximate if keys had to be evicted
+func (c *topCounts[K]) snapshot(stats *Stats) map[K]int {
+	if c.evicted {
+		stats.Approximate = true
+	}
+	counts := make(map[K]int, len(c.entries))
+	for _, e := range c.entries {
+		counts[e.key] = e.count - e.err
+	}
+	return counts
+}
+
+// pruneFloor returns the count at or below which entries have to be dropped
+// so that at most keep of them are left. it is for trackers that keep more
+// than a count per key and so can't use topCounts. counts is sorted in place
+func pruneFloor(counts []int, keep int) int {
+	if len(counts) <= keep {
+		return 0
+	}
+	slices.Sort(counts)
+	return counts[len(counts)-keep-1]
+}
+
+func (c *topCounts[K]) less(i, j int) bool {
+	return c.entries[i].count < c.entries[j].count
+}
+
+func (c *topCounts[K]) swap(i, j int) {
+	c.entries[i], c.entries[j] = c.entries[j], c.entries[i]
+	c.index[c.entries[i].key] = i
+	c.index[c.entries[j].key] = j
+}
+
+func (c *topCounts[K]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !c.less(i, parent) {
+			return
+		}
+		c.swap(i, parent)
+		i = parent
+	}
+}
+
+func (c *topCounts[K]) down(i int) {
+	for {
+		smallest := i
+		if left := 2*i + 1; left < len(c.entries) && c.less(left, smallest) {
+			smallest = left
+		}
+		if right := 2*i + 2; right < len(c.entries) && c.less(right, smallest) {
+			smallest = right
+		}
+		if smallest == i {
+			return
+		}
+		c.swap(i, smallest)
+		i = smallest
+	}
+}
@@ -0,0 +1,176 @@
+package analyzer
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestTopCountsExactUnderLimit(t *testing.T) {
+	c := newTopCounts[string](4)
+	for _, key := range []string{"a", "b", "a", "c", "a", "b"} {
+		c.add(key)
+	}
+	stats := &Stats{}
+	got := c.snapshot(stats)
+	if got["a"] != 3 || got["b"] != 2 || got["c"] != 1 || len(got) != 3 {
+		t.Errorf("snapshot = %v, want a:3 b:2 c:1", got)
+	}
+	if stats.Approximate || c.distinct() != 3 || c.count("z") != 0 {
+		t.Errorf("approximate %v, distinct %d, count(z) %d; want exact counts", stats.Approximate, c.distinct(), c.count("z"))
+	}
+}
+
+func TestTopCountsErrorBound(t *testing.T) {
+	const limit = 64
+	rng := rand.New(rand.NewSource(1))
+	c := newTopCounts[string](limit)
+	exact := make(map[string]int)
+
+	n := 0
+	add := func(key string) {
+		c.add(key)
+		exact[key]++
+		n++
+	}
+	for i := range 20_000 {
+		switch r := rng.Intn(10); {
+		case r < 3:
+			// a few heavy hitters
+			add(fmt.Sprintf("heavy%d", rng.Intn(5)))
+		case r < 6:
+			// a long tail that comes back now and then
+			add(fmt.Sprintf("tail%d", rng.Intn(500)))
+		default:
+			add(fmt.Sprintf("unique%d", i))
+		}
+	}
+
+	if c.distinct() > limit {
+		t.Fatalf("tracking %d keys, limit %d", c.distinct(), limit)
+	}
+	stats := &Stats{}
+	counts := c.snapshot(stats)
+	if !stats.Approximate {
+		t.Error("evicting keys didn't mark the stats approximate")
+	}
+
+	bound := n / limit
+	for key, count := range counts {
+		if count > exact[key] || exact[key]-count > bound {
+			t.Errorf("%s counted %d, seen %d times, error bound %d", key, count, exact[key], bound)
+		}
+	}
+	for key, seen := range exact {
+		if seen > bound && c.count(key) == 0 {
+			t.Errorf("%s seen %d times (> %d) but not tracked", key, seen, bound)
+		}
+	}
+	for i := range 5 {
+		if key := fmt.Sprintf("heavy%d", i); c.count(key) == 0 {
+			t.Errorf("lost heavy hitter %s", key)
+		}
+	}
+}
+
+func TestApproximateStats(t *testing.T) {
+	if stats := analyzeLines(t, "ls", "git status", "ls"); stats.Approximate {
+		t.Error("a small history was marked approximate")
+	}
+
+	var lines []string
+	for i := range maxTrackedKeys + 100 {
+		lines = append(lines, fmt.Sprintf("./script%d", i))
+	}
+	lines = append(lines, "ls", "ls", "ls")
+	stats := analyzeLines(t, lines...)
+	if !stats.Approximate {
+		t.Error("more distinct commands than can be tracked, but the stats claim to be exact")
+	}
+	if stats.TopCommands[0] != (CommandCount{Command: "ls", Count: 3}) {
+		t.Errorf("top command = %+v, want ls x3", stats.TopCommands[0])
+	}
+}
+
+func TestPruneFloor(t *testing.T) {
+	tests := []struct {
+		counts []int
+		keep   int
+		want   int
+	}{
+		{[]int{1, 2, 3}, 3, 0},
+		{[]int{1, 2, 3}, 5, 0},
+		{[]int{5, 1, 3, 2}, 2, 2},
+		{[]int{1, 1, 1, 9}, 2, 1},
+		{[]int{4, 4, 4, 4}, 2, 4}, // ties go together
+	}
+	for _, tt := range tests {
+		counts := append([]int(nil), tt.counts...)
+		floor := pruneFloor(counts, tt.keep)
+		if floor != tt.want {
+			t.Errorf("pruneFloor(%v, %d) = %d, want %d", tt.counts, tt.keep, floor, tt.want)
+		}
+		kept := 0
+		for _, count := range tt.counts {
+			if count > floor {
+				kept++
+			}
+		}
+		if kept > tt.keep {
+			t.Errorf("pruneFloor(%v, %d) keeps %d", tt.counts, tt.keep, kept)
+		}
+	}
+}
+
+func TestTrackersStayBounded(t *testing.T) {
+	adoption := newAdoptionTracker(newTopCounts[string](maxTrackedKeys))
+	flags := newFlagTracker()
+	typos := newTypoTracker(newTopCounts[string](maxTrackedKeys))
+	editors := newEditorTracker()
+	accumulators := []Accumulator{adoption, flags, typos, editors}
+
+	add := func(line string) {
+		cmd := parser.ParseStage(line)
+		cmd.Timestamp, cmd.HasTime = testNow, true
+		e := newEvent(cmd, false)
+		for _, acc := range accumulators {
+			acc.Add(&e)
+		}
+	}
+	for range 10 {
+		add("git status -s")
+	}
+	// one-off scripts, typos retyped nearby and files with made up extensions
+	for i := range maxTrackedKeys + 100 {
+		add(fmt.Sprintf("./run%d -v", i))
+		add(fmt.Sprintf("zq%d", i))
+		add(fmt.Sprintf("zq%dx", i))
+		add(fmt.Sprintf("vim notes.x%d", i))
+	}
+
+	if n := len(adoption.tools); n > maxTrackedKeys {
+		t.Errorf("adoption tracks %d commands", n)
+	}
+	if n := len(flags.invocations); n > maxTrackedKeys || len(flags.uses) > maxTrackedKeys || len(flags.flags) > maxTrackedKeys {
+		t.Errorf("flags track %d tools", n)
+	}
+	if n := typos.corrections.distinct(); n > maxTrackedKeys {
+		t.Errorf("typos track %d corrections", n)
+	}
+	if n := editors.extensions.distinct(); n > maxTrackedKeys {
+		t.Errorf("editors track %d extensions", n)
+	}
+	if adoption.tools["git"] == nil || flags.invocations["git status"] != 10 {
+		t.Error("pruning dropped the most used command")
+	}
+
+	for _, acc := range accumulators {
+		stats := &Stats{TotalCommands: 1, FirstCommand: testNow, LastCommand: testNow}
+		acc.Finish(stats)
+		if !stats.Approximate {
+			t.Errorf("%T dropped entries but didn't mark the stats approximate", acc)
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// project is a repository or top-level folder the user worked in
+type Project struct {
+	Name     string
+	Path     string
+	Commands int           // commands run inside it
+	Time     time.Duration // time spent, from gaps between commands within a session
+}
+
+// monthCount is a per-month tally, Month formatted as 2006-01
+type MonthCount struct {
+	Month string
+	Count int
+}
+
+const topProjectCount = 8
+
+// pushd without popd would grow the stack forever; shells cap theirs too
+const maxDirStack = 64
+
+// folders under which every child directory is a project (~/code/<project>)
+var projectRoots = []string{
+	"~/code", "~/src", "~/dev", "~/projects", "~/Projects", "~/repos", "~/git",
+	"~/work", "~/workspace", "~/Developer", "~/Documents/GitHub", "~/go/src",
+}
+
+// dirTracker reconstructs the working directory by replaying cd, pushd and
+// popd, then attributes commands and time to each directory
+type dirTracker struct {
+	cwd      string
+	previous string   // for cd -
+	stack    []string // pushd stack
+
+	lastDir  string
+	lastTime time.Time
+
+	cdTargets *topCounts[string]
+	commands  map[string]int
+	time      map[string]time.Duration
+	months    map[string]map[string]bool // dir -> active months
+	gitDirs   map[string]bool            // dirs git ran in, cloned or initialized
+	pruned    bool                       // directories were forgotten to bound memory
+}
+
+func newDirTracker() *dirTracker {
+	return &dirTracker{
+		cwd:       "~",
+		cdTargets: newTopCounts[string](maxTrackedKeys),
+		commands:  make(map[string]int),
+		time:      make(map[string]time.Duration),
+		months:    make(map[string]map[string]bool),
+		gitDirs:   make(map[string]bool),
+	}
+}
+
+func (d *dirTracker) Add(e *Event) {
+	cmd, baseCmd, newSession := e.Cmd, e.Base, e.NewSession
+	dir := d.cwd
+
+	d.commands[dir]++
+	d.prune()
+	if cmd.HasTime {
+		if !newSession && !d.lastTime.IsZero() && cmd.Timestamp.After(d.lastTime) {
+			d.time[d.lastDir] += cmd.Timestamp.Sub(d.lastTime)
+		}
+		d.lastDir, d.lastTime = dir, cmd.Timestamp
+
+		month := cmd.Timestamp.Format("2006-01")
+		if d.months[dir] == nil {
+			d.months[dir] = make(map[string]bool)
+		}
+		d.months[dir][month] = true
+	}
+
+	if len(e.Pipelines) <= 1 {
+		d.replay(cmd, baseCmd)
+		return
+	}
+	// replay a chain (cd api && make) one command at a time
+	for _, stages := range e.Pipelines {
+		for _, stage := range stages {
+			segment := parser.ParseStage(stage)
+			if segment == nil {
+				continue
+			}
+			base := parser.GetBaseCommand(segment)
+			// a cd inside a pipeline runs in a subshell and changes nothing
+			if len(stages) > 1 && base != "git" {
+				continue
+			}
+			d.replay(segment, base)
+		}
+	}
+}
+
+// replay applies one command that can change or mark the working directory
+func (d *dirTracker) replay(cmd *parser.Command, baseCmd string) {
+	args := commandArgs(cmd, baseCmd)
+	switch baseCmd {
+	case "cd":
+		d.cd(firstArg(args))
+	case "pushd":
+		target := firstArg(args)
+		if target == "" {
+			// pushd with no argument swaps the top two directories
+			if len(d.stack) > 0 {
+				top := d.stack[len(d.stack)-1]
+				d.stack[len(d.stack)-1] = d.cwd
+				d.chdir(top)
+			}
+			return
+		}
+		d.stack = append(d.stack, d.cwd)
+		if len(d.stack) > maxDirStack {
+			d.stack = d.stack[1:]
+		}
+		d.cd(target)
+	case "popd":
+		if len(d.stack) > 0 {
+			top := d.stack[len(d.stack)-1]
+			d.stack = d.stack[:len(d.stack)-1]
+			d.chdir(top)
+		}
+	case "git":
+		d.gitDirs[d.cwd] = true
+		if len(args) > 0 && (args[0] == "clone" || args[0] == "init") {
+			if target := cloneTarget(args); target != "" {
+				d.gitDirs[resolveDir(d.cwd, target)] = true
+			}
+		}
+	}
+}
+
+// cd applies a cd argument to the current directory
+func (d *dirTracker) cd(target string) {
+	switch {
+	case target == "-":
+		if d.previous != "" {
+			d.chdir(d.previous)
+		}
+	case target == "":
+		d.chdir("~")
+	case strings.ContainsAny(target, "$`*"):
+		// variables and globs can't be resolved from history alone
+		return
+	default:
+		dir := resolveDir(d.cwd, target)
+		d.cdTargets.add(dir)
+		d.chdir(dir)
+	}
+}
+
+func (d *dirTracker) chdir(dir string) {
+	if dir != d.cwd {
+		d.previous = d.cwd
+		d.cwd = dir
+	}
+}
+
+// prune forgets the least used directories once more than maxTrackedKeys
+// have been seen. the per-directory maps have to stay in step, so this
+// drops rare directories outright instead of using topCounts
+func (d *dirTracker) prune() {
+	if len(d.commands) <= maxTrackedKeys {
+		return
+	}
+	d.pruned = true
+	counts := make([]int, 0, len(d.commands))
+	for _, count := range d.commands {
+		counts = append(counts, count)
+	}
+	floor := pruneFloor(counts, maxTrackedKeys/2)
+	for dir, count := range d.commands {
+		if count <= floor && dir != d.cwd {
+			delete(d.commands, dir)
+			delete(d.time, dir)
+			delete(d.months, dir)
+			delete(d.gitDirs, dir)
+		}
+	}
+	// cloned directories that were never entered
+	if len(d.gitDirs) > maxTrackedKeys {
+		for dir := range d.gitDirs {
+			if d.commands[dir] == 0 {
+				delete(d.gitDirs, dir)
+			}
+		}
+	}
+}
+
+func (d *dirTracker) Finish(stats *Stats) {
+	if d.pruned {
+		stats.Approximate = true
+	}
+
+	// favorite directory: most visited resolved cd target, home aside
+	for dir, count := range d.cdTargets.snapshot(stats) {
+		if dir == "~" {
+			continue
+		}
+		if count > stats.FavoriteDirCount || (count == stats.FavoriteDirCount && dir < stats.FavoriteDir) {
+			stats.FavoriteDir = dir
+			stats.FavoriteDirCount = count
+		}
+	}
+
+	projects := make(map[string]*Project)
+	monthProjects := make(map[string]map[string]bool)
+	for dir, count := range d.commands {
+		root := d.projectOf(dir)
+		if root == "" {
+			continue
+		}
+		p := projects[root]
+		if p == nil {
+			p = &Project{Name: path.Base(root), Path: root}
+			projects[root] = p
+		}
+		p.Commands += count
+		p.Time += d.time[dir]
+
+		for month := range d.months[dir] {
+			if monthProjects[month] == nil {
+				monthProjects[month] = make(map[string]bool)
+			}
+			monthProjects[month][root] = true
+		}
+	}
+
+	for _, p := range projects {
+		stats.TopProjects = append(stats.TopProjects, *p)
+	}
+	sort.Slice(stats.TopProjects, func(i, j int) bool {
+		a, b := stats.TopProjects[i], stats.TopProjects[j]
+		if a.Commands != b.Commands {
+			return a.Commands > b.Commands
+		}
+		return a.Path < b.Path
+	})
+	stats.ProjectCount = len(stats.TopProjects)
+	if len(stats.TopProjects) > topProjectCount {
+		stats.TopProjects = stats.TopProjects[:topProjectCount]
+	}
+
+	for month, set := range monthProjects {
+		stats.ProjectsPerMonth = append(stats.ProjectsPerMonth, MonthCount{Month: month, Count: len(set)})
+	}
+	sort.Slice(stats.ProjectsPerMonth, func(i, j int) bool {
+		return stats.ProjectsPerMonth[i].Month < stats.ProjectsPerMonth[j].Month
+	})
+}
+
+// projectOf maps a directory to its project: the outermost directory git was
+// used in, or the child of a well-known projects folder
+func (d *dirTracker) projectOf(dir string) string {
+	if dir == "~" || dir == "/" {
+		return ""
+	}
+
+	// walk up from the top so the outermost git directory wins. system
+	// folders like /etc are not projects even when git runs there
+	parts := strings.Split(dir, "/")
+	first := 2
+	if parts[0] == "" {
+		first = 3
+	}
+	for i := first; i <= len(parts); i++ {
+		ancestor := strings.Join(parts[:i], "/")
+		if d.gitDirs[ancestor] && !isProjectRoot(ancestor) {
+			return ancestor
+		}
+	}
+
+	for _, root := range projectRoots {
+		if rest, ok := strings.CutPrefix(dir, root+"/"); ok {
+			name, _, _ := strings.Cut(rest, "/")
+			// go/src paths are host/owner/repo
+			if root == "~/go/src" {
+				segments := strings.SplitN(rest, "/", 4)
+				if len(segments) < 3 {
+					return ""
+				}
+				name = strings.Join(segments[:3], "/")
+			}
+			return root + "/" + name
+		}
+	}
+	return ""
+}
+
+func isProjectRoot(dir string) bool {
+	for _, root := range projectRoots {
+		if dir == root {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveDir applies a cd target to the current directory
+func resolveDir(cwd, target string) string {
+	target = strings.Trim(target, `"'`)
+	switch {
+	case target == "~" || strings.HasPrefix(target, "~/"), strings.HasPrefix(target, "/"):
+		return normalizeDir(target)
+	default:
+		return normalizeDir(cwd + "/" + target)
+	}
+}
+
+// normalizeDir cleans a path, keeping ~ as the root of home paths
+func normalizeDir(dir string) string {
+	if dir == "~" || strings.HasPrefix(dir, "~/") {
+		cleaned := path.Clean("/" + strings.TrimPrefix(dir, "~"))
+		if cleaned == "/" {
+			return "~"
+		}
+		return "~" + cleaned
+	}
+	return path.Clean(dir)
+}
+
+// commandArgs returns the arguments after the base command, dropping sudo
+// and other wrappers in front of it
+func commandArgs(cmd *parser.Command, baseCmd string) []string {
+	if cmd.Command == baseCmd {
+		return cmd.Args
+	}
+	for i, arg := range cmd.Args {
+		if arg == baseCmd {
+			return cmd.Args[i+1:]
+		}
+	}
+	return nil
+}
+
+// firstArg returns the first non-flag argument, stopping at shell operators
+func firstArg(args []string) string {
+	for _, arg := range args {
+		if isShellOperator(arg) {
+			return ""
+		}
+		arg = strings.TrimRight(arg, ";")
+		if arg == "-" || !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
+
+func isShellOperator(arg string) bool {
+	switch arg {
+	case "&&", "||", ";", "|", "&":
+		return true
+	}
+	return false
+}
+
+// git clone/init flags that take a value
+var cloneValueFlags = map[string]bool{
+	"-b": true, "--branch": true, "--depth": true, "-o": true, "--origin": true,
+	"-c": true, "--config": true, "--reference": true, "--separate-git-dir": true,
+}
+
+// cloneTarget returns the directory git clone/init creates
+func cloneTarget(args []string) string {
+	var positional []string
+	skipValue := false
+	for _, arg := range args[1:] {
+		if isShellOperator(arg) {
+			break
+		}
+		if skipValue {
+			skipValue = false
+			continue
+		}
+		if cloneValueFlags[arg] {
+			skipValue = true
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, strings.Trim(arg, `"'`))
+		}
+	}
+	if args[0] == "init" {
+		if len(positional) == 0 {
+			return "."
+		}
+		return positional[0]
+	}
+	switch len(positional) {
+	case 0:
+		return ""
+	case 1:
+		// git clone git@github.com:user/repo.git -> repo
+		repo := positional[0]
+		if i := strings.LastIndexAny(repo, "/:"); i >= 0 {
+			repo = repo[i+1:]
+		}
+		return strings.TrimSuffix(repo, ".git")
+	default:
+		return positional[1]
+	}
+}
@@ -0,0 +1,160 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestResolveDir(t *testing.T) {
+	tests := []struct {
+		cwd, target, want string
+	}{
+		{"~", "code", "~/code"},
+		{"~/code/api", "..", "~/code"},
+		{"~/code", "../..", "~"}, // home is the root of home paths
+		{"~/code", "/etc", "/etc"},
+		{"/tmp", "~/src/", "~/src"},
+		{"~", `"My Projects"`, "~/My Projects"},
+		{"~", "~", "~"},
+	}
+	for _, tt := range tests {
+		if got := resolveDir(tt.cwd, tt.target); got != tt.want {
+			t.Errorf("resolveDir(%q, %q) = %q, want %q", tt.cwd, tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestCloneTarget(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{"clone git@github.com:user/repo.git", "repo"},
+		{"clone https://github.com/user/tool", "tool"},
+		{"clone --depth 1 -b main https://host/x/y.git dest", "dest"},
+		{"init", "."},
+		{"init fresh", "fresh"},
+		{"clone", ""},
+	}
+	for _, tt := range tests {
+		if got := cloneTarget(strings.Fields(tt.args)); got != tt.want {
+			t.Errorf("cloneTarget(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+// replayDirs runs lines through a dirTracker and returns it
+func replayDirs(lines ...string) *dirTracker {
+	d := newDirTracker()
+	for _, line := range lines {
+		e := newEvent(parser.ParseStage(line), false)
+		d.Add(&e)
+	}
+	return d
+}
+
+func TestDirReplay(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"cd", []string{"cd ~/code/api", "cd src"}, "~/code/api/src"},
+		{"cd home", []string{"cd /etc", "cd"}, "~"},
+		{"cd -", []string{"cd /etc", "cd /tmp", "cd -"}, "/etc"},
+		{"pushd popd", []string{"cd ~/a", "pushd /tmp", "pushd /var", "popd"}, "/tmp"},
+		{"pushd swap", []string{"cd ~/a", "pushd /tmp", "pushd"}, "~/a"},
+		{"variables", []string{"cd ~/a", "cd $PROJECT"}, "~/a"},
+		{"chain", []string{"cd ~/code/api && make"}, "~/code/api"},
+		{"long chain", []string{"cd ~/code; cd api || exit; ls"}, "~/code/api"},
+		{"subshell", []string{"(cd /tmp && make)"}, "~"},
+		{"pipeline", []string{"cd /tmp | cat"}, "/tmp"},
+		{"cd in a pipeline of a chain", []string{"ls && cd /tmp | cat"}, "~"},
+	}
+	for _, tt := range tests {
+		if got := replayDirs(tt.lines...).cwd; got != tt.want {
+			t.Errorf("%s: cwd = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestDirStackIsBounded(t *testing.T) {
+	var lines []string
+	for range maxDirStack * 2 {
+		lines = append(lines, "pushd /tmp")
+	}
+	if d := replayDirs(lines...); len(d.stack) > maxDirStack {
+		t.Errorf("pushd stack grew to %d, limit %d", len(d.stack), maxDirStack)
+	}
+}
+
+func TestDirsArePruned(t *testing.T) {
+	lines := []string{"cd ~/code/api", "make", "make", "make"}
+	for i := range maxTrackedKeys + 1 {
+		lines = append(lines, fmt.Sprintf("cd /tmp/run%d", i), "ls")
+	}
+	d := replayDirs(lines...)
+	if len(d.commands) > maxTrackedKeys/2+1 {
+		t.Errorf("%d directories left after pruning, want at most %d", len(d.commands), maxTrackedKeys/2+1)
+	}
+	if d.commands["~/code/api"] != 4 {
+		t.Error("pruning dropped the busiest directory")
+	}
+	if d.commands[d.cwd] == 0 {
+		t.Error("pruning dropped the current directory")
+	}
+	stats := &Stats{}
+	if d.Finish(stats); !stats.Approximate {
+		t.Error("directories were dropped but the stats claim to be exact")
+	}
+}
+
+func TestProjects(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(at(3, 9, 0), "cd ~/work/shop && git status"),
+		timed(at(3, 9, 10), "vim main.go"),
+		timed(at(3, 9, 20), "cd ~/code/api"),
+		timed(at(3, 9, 30), "make"),
+		timed(at(3, 9, 40), "cd ~/work/shop/web"),
+		timed(at(3, 9, 45), "npm test"),
+		timed(at(3, 9, 50), "cd /etc && sudo git diff"),
+		timed(at(3, 9, 55), "cd"),
+	)
+
+	projects := make(map[string]Project)
+	for _, p := range stats.TopProjects {
+		projects[p.Path] = p
+	}
+	if len(projects) != 2 {
+		t.Fatalf("TopProjects = %+v, want ~/work/shop and ~/code/api", stats.TopProjects)
+	}
+	// git ran in ~/work/shop, so its subfolders belong to it
+	shop := projects["~/work/shop"]
+	if shop.Name != "shop" || shop.Commands != 4 {
+		t.Errorf("shop = %+v, want 4 commands", shop)
+	}
+	if shop.Time != 30*time.Minute {
+		t.Errorf("shop time = %v, want 30m", shop.Time)
+	}
+	// ~/code children are projects without git
+	if api := projects["~/code/api"]; api.Commands != 2 {
+		t.Errorf("api = %+v, want 2 commands", api)
+	}
+	if stats.ProjectCount != 2 {
+		t.Errorf("ProjectCount = %d, want 2", stats.ProjectCount)
+	}
+	if len(stats.ProjectsPerMonth) != 1 || stats.ProjectsPerMonth[0] != (MonthCount{Month: "2025-02", Count: 2}) {
+		t.Errorf("ProjectsPerMonth = %+v", stats.ProjectsPerMonth)
+	}
+}
+
+func TestFavoriteDir(t *testing.T) {
+	stats := analyzeLines(t, "cd ~/a", "cd ~/b", "cd ~/a", "cd", "cd", "cd")
+	if stats.FavoriteDir != "~/a" || stats.FavoriteDirCount != 2 {
+		t.Errorf("favorite dir = %q x%d, want ~/a x2", stats.FavoriteDir, stats.FavoriteDirCount)
+	}
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"path"
+	"strings"
+)
+
+const topEditedCount = 5
+
+var editors = map[string]bool{
+	"vim": true, "nvim": true, "vi": true, "nano": true, "emacs": true, "code": true, "cursor": true,
+	"subl": true, "micro": true, "hx": true, "helix": true,
+}
+
+// config files worth calling out when edited (matched on the file name)
+var configFiles = map[string]bool{
+	".zshrc": true, ".zprofile": true, ".zshenv": true, ".bashrc": true, ".bash_profile": true,
+	".profile": true, ".bash_aliases": true, "config.fish": true, ".vimrc": true, "init.vim": true,
+	"init.lua": true, ".gitconfig": true, ".gitignore": true, ".tmux.conf": true, ".env": true,
+	".editorconfig": true, "Dockerfile": true, "docker-compose.yml": true, "docker-compose.yaml": true,
+	"compose.yml": true, "compose.yaml": true, "Makefile": true, "Justfile": true, "package.json": true,
+	"tsconfig.json": true, "go.mod": true, "Cargo.toml": true, "pyproject.toml": true,
+	"requirements.txt": true, "Gemfile": true, "hosts": true, "crontab": true, "sshd_config": true,
+	".wezterm.lua": true, "alacritty.toml": true, "kitty.conf": true, "starship.toml": true,
+}
+
+// editor options that take a value (vim -c cmd, code --goto file:line is a file)
+var editorValueFlags = map[string]bool{
+	"-c": true, "--cmd": true, "-u": true, "-S": true, "-t": true, "-q": true, "-i": true,
+	"--user-data-dir": true, "--extensions-dir": true, "--profile": true, "--eval": true,
+}
+
+// editorTracker counts editors and the files opened with them
+type editorTracker struct {
+	editors    map[string]int
+	files      *topCounts[string]
+	extensions *topCounts[string]
+	configs    map[string]int
+	fileCount  int
+}
+
+func newEditorTracker() *editorTracker {
+	return &editorTracker{
+		editors:    make(map[string]int),
+		files:      newTopCounts[string](maxTrackedKeys),
+		extensions: newTopCounts[string](maxTrackedKeys),
+		configs:    make(map[string]int),
+	}
+}
+
+func (t *editorTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	if !editors[baseCmd] {
+		return
+	}
+	t.editors[baseCmd]++
+
+	for _, file := range positionalArgs(commandArgs(cmd, baseCmd), editorValueFlags) {
+		file = editedFile(file)
+		if file == "" {
+			continue
+		}
+		t.fileCount++
+		t.files.add(file)
+
+		name := path.Base(file)
+		if configFiles[name] {
+			t.configs[name]++
+		}
+		if ext := strings.ToLower(path.Ext(name)); ext != "" && ext != name {
+			t.extensions.add(ext)
+		}
+	}
+}
+
+func (t *editorTracker) Finish(stats *Stats) {
+	stats.EditorSplit = topN(t.editors, len(t.editors))
+	if len(stats.EditorSplit) > 0 {
+		stats.EditorChoice = stats.EditorSplit[0].Command
+		stats.EditorCount = stats.EditorSplit[0].Count
+	}
+	stats.EditedFiles = t.fileCount
+	stats.TopEditedFiles = topN(t.files.snapshot(stats), topEditedCount)
+	stats.TopEditedExtensions = topN(t.extensions.snapshot(stats), topEditedCount)
+	stats.TopConfigFiles = topN(t.configs, topEditedCount)
+}
+
+// editedFile cleans an editor argument into a file path, or "" when it is
+// a directory or a vim +cmd
+func editedFile(arg string) string {
+	if arg == "" || strings.HasPrefix(arg, "+") || strings.HasSuffix(arg, "/") || strings.Contains(arg, "://") {
+		return ""
+	}
+	// code --goto main.go:12:4
+	if file, _, found := strings.Cut(arg, ":"); found && file != "" {
+		arg = file
+	}
+	file := path.Clean(arg)
+	if file == "." || file == ".." || file == "~" {
+		return ""
+	}
+	return file
+}
@@ -0,0 +1,60 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEditedFile(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want string
+	}{
+		{"main.go", "main.go"},
+		{"./src/../src/app.ts", "src/app.ts"},
+		{"main.go:12:4", "main.go"},
+		{"+42", ""},
+		{"src/", ""},
+		{".", ""},
+		{"~", ""},
+		{"scp://host/file", ""},
+	}
+	for _, tt := range tests {
+		if got := editedFile(tt.arg); got != tt.want {
+			t.Errorf("editedFile(%q) = %q, want %q", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestEditors(t *testing.T) {
+	stats := analyzeLines(t,
+		"vim main.go",
+		"nvim -c 'set nu' main.go util.go",
+		"vim ~/.zshrc",
+		"code --goto main.go:10 .",
+		"nano +5 notes.txt",
+		"vim src/",
+		"ls main.go",
+	)
+
+	want := []CommandCount{{"vim", 3}, {"code", 1}, {"nano", 1}, {"nvim", 1}}
+	if !reflect.DeepEqual(stats.EditorSplit, want) {
+		t.Errorf("EditorSplit = %+v, want %+v", stats.EditorSplit, want)
+	}
+	if stats.EditorChoice != "vim" || stats.EditorCount != 3 {
+		t.Errorf("editor choice = %s x%d, want vim x3", stats.EditorChoice, stats.EditorCount)
+	}
+	if stats.EditedFiles != 6 {
+		t.Errorf("EditedFiles = %d, want 6", stats.EditedFiles)
+	}
+	if top := stats.TopEditedFiles[0]; top != (CommandCount{"main.go", 3}) {
+		t.Errorf("top file = %+v, want main.go x3", top)
+	}
+	// dotfiles have no extension of their own
+	if want := []CommandCount{{".go", 4}, {".txt", 1}}; !reflect.DeepEqual(stats.TopEditedExtensions, want) {
+		t.Errorf("TopEditedExtensions = %+v, want %+v", stats.TopEditedExtensions, want)
+	}
+	if want := []CommandCount{{".zshrc", 1}}; !reflect.DeepEqual(stats.TopConfigFiles, want) {
+		t.Errorf("TopConfigFiles = %+v, want %+v", stats.TopConfigFiles, want)
+	}
+}
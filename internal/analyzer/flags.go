@@ -0,0 +1,208 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+)
+
+// toolFlags lists the most used flags of one tool (or tool subcommand)
+type ToolFlags struct {
+	Tool  string
+	Uses  int // invocations with at least one flag
+	Flags []CommandCount
+}
+
+// flagHeaviness is how many flags a tool gets on average
+type FlagHeaviness struct {
+	Tool     string
+	Uses     int
+	AvgFlags float64
+}
+
+const (
+	topFlagTools      = 6
+	topFlagsPerTool   = 5
+	minFlagHeavyUses  = 5
+	topFlagHeavyCount = 5
+)
+
+// tools whose options are words behind a single dash (find -name, go test -run)
+var singleDashTools = map[string]bool{
+	"find": true, "go": true, "java": true, "ffmpeg": true, "gcc": true, "clang": true,
+	"terraform": true, "openssl": true, "xcodebuild": true, "convert": true, "magick": true,
+}
+
+// flagTracker counts options per tool
+type flagTracker struct {
+	flags       map[string]map[string]int // tool -> flag -> count
+	uses        map[string]int            // tool -> invocations with flags
+	invocations map[string]int            // tool -> all invocations
+	totals      map[string]int            // tool -> flags over all invocations
+
+	total       int
+	mostFlags   int
+	mostFlagged string
+	pruned      bool // rarely used tools were forgotten
+}
+
+func newFlagTracker() *flagTracker {
+	return &flagTracker{
+		flags:       make(map[string]map[string]int),
+		uses:        make(map[string]int),
+		invocations: make(map[string]int),
+		totals:      make(map[string]int),
+	}
+}
+
+func (f *flagTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	tool := commandKey(cmd, baseCmd)
+	flags := parseFlags(commandArgs(cmd, baseCmd), singleDashTools[baseCmd])
+
+	if _, ok := f.invocations[tool]; !ok && len(f.invocations) >= maxTrackedKeys {
+		f.prune()
+	}
+	f.invocations[tool]++
+	if len(flags) == 0 {
+		return
+	}
+	f.uses[tool]++
+	f.totals[tool] += len(flags)
+	f.total += len(flags)
+
+	if f.flags[tool] == nil {
+		f.flags[tool] = make(map[string]int)
+	}
+	for _, flag := range flags {
+		f.flags[tool][flag]++
+	}
+
+	if len(flags) > f.mostFlags {
+		f.mostFlags = len(flags)
+		f.mostFlagged = cmd.Raw
+	}
+}
+
+// prune forgets the least used tools, keeping the per-tool maps in step
+func (f *flagTracker) prune() {
+	f.pruned = true
+	counts := make([]int, 0, len(f.invocations))
+	for _, count := range f.invocations {
+		counts = append(counts, count)
+	}
+	floor := pruneFloor(counts, maxTrackedKeys/2)
+	for tool, count := range f.invocations {
+		if count <= floor {
+			delete(f.invocations, tool)
+			delete(f.uses, tool)
+			delete(f.totals, tool)
+			delete(f.flags, tool)
+		}
+	}
+}
+
+func (f *flagTracker) Finish(stats *Stats) {
+	if f.pruned {
+		stats.Approximate = true
+	}
+	stats.FlagCount = f.total
+	stats.MostFlagsCommand = f.mostFlagged
+	stats.MostFlagsCount = f.mostFlags
+	if stats.TotalCommands > 0 {
+		stats.FlagsPerCommand = float64(f.total) / float64(stats.TotalCommands)
+	}
+
+	distinct := make(map[string]bool)
+	for tool, flags := range f.flags {
+		for flag := range flags {
+			distinct[tool+" "+flag] = true
+		}
+		stats.TopFlags = append(stats.TopFlags, ToolFlags{Tool: tool, Uses: f.uses[tool], Flags: topN(flags, topFlagsPerTool)})
+	}
+	stats.DistinctFlags = len(distinct)
+
+	sort.Slice(stats.TopFlags, func(i, j int) bool {
+		a, b := stats.TopFlags[i], stats.TopFlags[j]
+		if a.Uses != b.Uses {
+			return a.Uses > b.Uses
+		}
+		return a.Tool < b.Tool
+	})
+	if len(stats.TopFlags) > topFlagTools {
+		stats.TopFlags = stats.TopFlags[:topFlagTools]
+	}
+
+	for tool, total := range f.totals {
+		if f.invocations[tool] < minFlagHeavyUses {
+			continue
+		}
+		stats.FlagHeavy = append(stats.FlagHeavy, FlagHeaviness{
+			Tool:     tool,
+			Uses:     f.invocations[tool],
+			AvgFlags: float64(total) / float64(f.invocations[tool]),
+		})
+	}
+	sort.Slice(stats.FlagHeavy, func(i, j int) bool {
+		a, b := stats.FlagHeavy[i], stats.FlagHeavy[j]
+		if a.AvgFlags != b.AvgFlags {
+			return a.AvgFlags > b.AvgFlags
+		}
+		return a.Tool < b.Tool
+	})
+	if len(stats.FlagHeavy) > topFlagHeavyCount {
+		stats.FlagHeavy = stats.FlagHeavy[:topFlagHeavyCount]
+	}
+}
+
+// parseFlags extracts the options from a command's arguments. combined short
+// flags are split (-xvf -> -x -v -f) and --opt=value keeps only --opt
+func parseFlags(args []string, singleDash bool) []string {
+	var flags []string
+	for _, arg := range args {
+		if isShellOperator(arg) || arg == "--" {
+			break
+		}
+		if len(arg) < 2 || arg[0] != '-' || strings.ContainsAny(arg, "\"'") {
+			continue
+		}
+
+		if strings.HasPrefix(arg, "--") {
+			name, _, _ := strings.Cut(arg, "=")
+			flags = append(flags, name)
+			continue
+		}
+
+		if singleDash || len(arg) == 2 || !allLetters(arg[1:]) {
+			name, _, _ := strings.Cut(arg, "=")
+			flags = append(flags, name)
+			continue
+		}
+		for _, r := range arg[1:] {
+			flags = append(flags, "-"+string(r))
+		}
+	}
+	return flags
+}
+
+func allLetters(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+			return false
+		}
+	}
+	return true
+}
+
+// getFlagLevel returns a fun label for the average number of flags per command
+func GetFlagLevel(perCommand float64) string {
+	switch {
+	case perCommand < 0.3:
+		return "Minimalist"
+	case perCommand < 0.7:
+		return "Casual"
+	case perCommand < 1.2:
+		return "Tinkerer"
+	default:
+		return "Flag Hoarder"
+	}
+}
@@ -0,0 +1,77 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	tests := []struct {
+		args       string
+		singleDash bool
+		want       []string
+	}{
+		{"-xvf archive.tar", false, []string{"-x", "-v", "-f"}},
+		{"--depth=1 --branch main", false, []string{"--depth", "--branch"}},
+		{"-n prod", false, []string{"-n"}},
+		{"-9 1234", false, []string{"-9"}},
+		{"-name *.go -type f", true, []string{"-name", "-type"}},
+		{"-run=TestX ./...", true, []string{"-run"}},
+		{"-la | grep -v x", false, []string{"-l", "-a"}},
+		{"-- -not-a-flag", false, nil},
+		{`-m "-v in a message"`, false, []string{"-m"}},
+		{"- file", false, nil},
+	}
+	for _, tt := range tests {
+		if got := parseFlags(strings.Fields(tt.args), tt.singleDash); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseFlags(%q, %v) = %q, want %q", tt.args, tt.singleDash, got, tt.want)
+		}
+	}
+}
+
+func TestFlags(t *testing.T) {
+	stats := analyzeLines(t,
+		"ls -la",
+		"ls -l",
+		"ls",
+		"tar -xzvf a.tgz",
+		"git commit -am wip",
+		"git commit -m done",
+	)
+
+	if stats.FlagCount != 10 {
+		t.Errorf("FlagCount = %d, want 10", stats.FlagCount)
+	}
+	if stats.MostFlagsCommand != "tar -xzvf a.tgz" || stats.MostFlagsCount != 4 {
+		t.Errorf("most flags = %q (%d), want the tar line with 4", stats.MostFlagsCommand, stats.MostFlagsCount)
+	}
+	// tools with more flagged invocations first, subcommands kept apart
+	if len(stats.TopFlags) != 3 || stats.TopFlags[0].Tool != "git commit" || stats.TopFlags[1].Tool != "ls" {
+		t.Fatalf("TopFlags = %+v", stats.TopFlags)
+	}
+	if top := stats.TopFlags[1].Flags[0]; top != (CommandCount{Command: "-l", Count: 2}) {
+		t.Errorf("top ls flag = %+v, want -l x2", top)
+	}
+	// -l and -a for ls, -x -z -v -f for tar, -a and -m for git commit
+	if stats.DistinctFlags != 8 {
+		t.Errorf("DistinctFlags = %d, want 8", stats.DistinctFlags)
+	}
+}
+
+func TestFlagHeavy(t *testing.T) {
+	var lines []string
+	for range minFlagHeavyUses {
+		lines = append(lines, "rsync -avz src dst", "ls -l")
+	}
+	// too few runs to count
+	lines = append(lines, "tar -xzvf a.tgz")
+
+	stats := analyzeLines(t, lines...)
+	if len(stats.FlagHeavy) != 2 {
+		t.Fatalf("FlagHeavy = %+v, want rsync and ls", stats.FlagHeavy)
+	}
+	if top := stats.FlagHeavy[0]; top.Tool != "rsync" || top.AvgFlags != 3 || top.Uses != minFlagHeavyUses {
+		t.Errorf("heaviest = %+v, want rsync averaging 3 flags", top)
+	}
+}
@@ -0,0 +1,308 @@
+package analyzer
+
+import (
+	"path"
+	"sort"
+	"strings"
+)
+
+// gitStats is the git deep dive
+type GitStats struct {
+	Commands    int
+	Commits     int // including amends
+	Amends      int
+	Pushes      int
+	ForcePushes int
+	Rebases     int // git rebase and git pull --rebase
+	Merges      int
+	Stashes     int
+	StashPops   int // pop and apply
+	Statuses    int
+	StatusRatio float64 // git status per commit
+
+	TopBranches     []CommandCount // branches switched to most
+	BranchesCreated int
+
+	// commit messages from -m/--message
+	MessageCount     int
+	AvgMessageLen    float64
+	MedianMessageLen int
+	ShortestMessage  string
+	LongestMessage   string
+	LazyMessages     int // "wip", "fix", "." and friends
+}
+
+const (
+	topBranchCount = 5
+	// shorter messages are lazy
+	lazyMessageLen = 5
+)
+
+var lazyMessages = map[string]bool{
+	"wip": true, "fix": true, "fixes": true, "update": true, "updates": true,
+	"stuff": true, "changes": true, "asdf": true, "tmp": true, "test": true,
+}
+
+// git options that take a value before the subcommand (git -C dir status)
+var gitValueOptions = map[string]bool{
+	"-C": true, "-c": true, "--git-dir": true, "--work-tree": true, "--namespace": true,
+}
+
+// top-level folders that make a checkout argument a path (git checkout
+// src/api), not a branch like feature/login
+var sourceDirs = map[string]bool{
+	"src": true, "lib": true, "cmd": true, "pkg": true, "internal": true, "app": true,
+	"test": true, "tests": true, "docs": true, "config": true, "scripts": true, "bin": true,
+}
+
+// gitTracker collects the git deep dive
+type gitTracker struct {
+	git         GitStats
+	branches    *topCounts[string]
+	messageLens map[int]int // message length -> messages of that length
+	messageSum  int
+}
+
+func newGitTracker() *gitTracker {
+	return &gitTracker{branches: newTopCounts[string](maxTrackedKeys), messageLens: make(map[int]int)}
+}
+
+func (g *gitTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	if baseCmd != "git" {
+		return
+	}
+	g.git.Commands++
+
+	args := commandArgs(cmd, baseCmd)
+	sub, rest := gitSubcommand(args)
+	switch sub {
+	case "commit":
+		g.git.Commits++
+		if hasArg(rest, "--amend") {
+			g.git.Amends++
+		}
+		for _, message := range commitMessages(rest) {
+			g.addMessage(message)
+		}
+	case "push":
+		g.git.Pushes++
+		if hasArg(rest, "--force", "-f", "--force-with-lease") || hasShortFlag(rest, 'f') {
+			g.git.ForcePushes++
+		}
+	case "pull":
+		if hasArg(rest, "--rebase", "-r") {
+			g.git.Rebases++
+		}
+	case "rebase":
+		// --continue/--abort are part of the same rebase
+		if !hasArg(rest, "--continue", "--abort", "--skip") {
+			g.git.Rebases++
+		}
+	case "merge":
+		if !hasArg(rest, "--continue", "--abort") {
+			g.git.Merges++
+		}
+	case "stash":
+		action := firstArg(rest)
+		switch action {
+		case "pop", "apply":
+			g.git.StashPops++
+		case "", "push", "save":
+			g.git.Stashes++
+		}
+	case "status":
+		g.git.Statuses++
+	case "checkout", "switch":
+		g.addBranch(rest)
+	}
+}
+
+// addBranch records the branch a checkout or switch moves to
+func (g *gitTracker) addBranch(args []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isShellOperator(arg) || arg == "--" {
+			return
+		}
+		switch arg {
+		case "-b", "-B", "-c", "-C", "--create", "--orphan":
+			g.git.BranchesCreated++
+			continue
+		case "-":
+			return
+		}
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		if isBranchName(arg) {
+			g.branches.add(arg)
+		}
+		return
+	}
+}
+
+// isBranchName tells a checkout argument naming a branch from one naming
+// files (main.go, Makefile, src/api) or a revision (HEAD~1, a1b2c3d)
+func isBranchName(arg string) bool {
+	first, _, nested := strings.Cut(arg, "/")
+	switch {
+	case strings.ContainsAny(arg, ".~^:@{*"),
+		strings.HasPrefix(arg, "/"), strings.HasSuffix(arg, "/"),
+		nested && sourceDirs[first],
+		configFiles[path.Base(arg)],
+		arg == "HEAD", isCommitHash(arg):
+		return false
+	}
+	return true
+}
+
+// isCommitHash reports whether s looks like an abbreviated or full commit
+// id. it needs a digit so words like "added" stay branches
+func isCommitHash(s string) bool {
+	if len(s) < 7 || len(s) > 40 || !strings.ContainsAny(s, "0123456789") {
+		return false
+	}
+	for _, r := range s {
+		if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+func (g *gitTracker) addMessage(message string) {
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return
+	}
+	g.git.MessageCount++
+	g.messageLens[len(message)]++
+	g.messageSum += len(message)
+	if g.git.ShortestMessage == "" || len(message) < len(g.git.ShortestMessage) {
+		g.git.ShortestMessage = message
+	}
+	if len(message) > len(g.git.LongestMessage) {
+		g.git.LongestMessage = message
+	}
+	if len(message) <= lazyMessageLen || lazyMessages[strings.ToLower(strings.Trim(message, ".!"))] {
+		g.git.LazyMessages++
+	}
+}
+
+func (g *gitTracker) Finish(stats *Stats) {
+	if g.git.Commands == 0 {
+		return
+	}
+
+	if g.git.Commits > 0 {
+		g.git.StatusRatio = float64(g.git.Statuses) / float64(g.git.Commits)
+	}
+	g.git.TopBranches = topN(g.branches.snapshot(stats), topBranchCount)
+	// messages are shown as typed, so mask secrets first
+	g.git.ShortestMessage = Redact(g.git.ShortestMessage)
+	g.git.LongestMessage = Redact(g.git.LongestMessage)
+
+	if g.git.MessageCount > 0 {
+		g.git.AvgMessageLen = float64(g.messageSum) / float64(g.git.MessageCount)
+		g.git.MedianMessageLen = medianLength(g.messageLens, g.git.MessageCount)
+	}
+
+	stats.Git = g.git
+}
+
+// medianLength returns the upper median of a histogram holding count lengths
+func medianLength(lengths map[int]int, count int) int {
+	keys := make([]int, 0, len(lengths))
+	for n := range lengths {
+		keys = append(keys, n)
+	}
+	sort.Ints(keys)
+
+	seen := 0
+	for _, n := range keys {
+		seen += lengths[n]
+		if seen > count/2 {
+			return n
+		}
+	}
+	return 0
+}
+
+// gitSubcommand skips global options and returns the subcommand and its args
+func gitSubcommand(args []string) (string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isShellOperator(arg) {
+			return "", nil
+		}
+		if gitValueOptions[arg] {
+			i++
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		return arg, args[i+1:]
+	}
+	return "", nil
+}
+
+// commitMessages extracts -m/--message values (git commit -am "msg" included)
+func commitMessages(args []string) []string {
+	var messages []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isShellOperator(arg) {
+			break
+		}
+		switch {
+		case strings.HasPrefix(arg, "--message="):
+			messages = append(messages, unquote(strings.TrimPrefix(arg, "--message=")))
+		case strings.HasPrefix(arg, "-m") && len(arg) > 2 && !strings.HasPrefix(arg, "--"):
+			// -m"msg" attached to the flag
+			messages = append(messages, unquote(arg[2:]))
+		case arg == "--message" || (strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && strings.HasSuffix(arg, "m")):
+			if i+1 < len(args) {
+				messages = append(messages, unquote(args[i+1]))
+				i++
+			}
+		}
+	}
+	return messages
+}
+
+// unquote strips one pair of matching surrounding quotes
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func hasArg(args []string, names ...string) bool {
+	for _, arg := range args {
+		if isShellOperator(arg) {
+			return false
+		}
+		for _, name := range names {
+			if arg == name || strings.HasPrefix(arg, name+"=") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hasShortFlag reports whether a combined short flag group contains flag (-uf)
+func hasShortFlag(args []string, flag rune) bool {
+	for _, arg := range args {
+		if isShellOperator(arg) {
+			return false
+		}
+		if len(arg) > 1 && arg[0] == '-' && arg[1] != '-' && allLetters(arg[1:]) && strings.ContainsRune(arg[1:], flag) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,183 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitSubcommand(t *testing.T) {
+	tests := []struct {
+		args string
+		sub  string
+		rest []string
+	}{
+		{"status", "status", []string{}},
+		{"-C ~/code/api log --oneline", "log", []string{"--oneline"}},
+		{"-c core.pager=cat --no-pager diff", "diff", []string{}},
+		{"--version", "", nil},
+		{"&& ls", "", nil},
+	}
+	for _, tt := range tests {
+		sub, rest := gitSubcommand(strings.Fields(tt.args))
+		if sub != tt.sub || len(rest) != len(tt.rest) || (len(rest) > 0 && !reflect.DeepEqual(rest, tt.rest)) {
+			t.Errorf("gitSubcommand(%q) = %q %q, want %q %q", tt.args, sub, rest, tt.sub, tt.rest)
+		}
+	}
+}
+
+func TestCommitMessages(t *testing.T) {
+	tests := []struct {
+		args []string
+		want []string
+	}{
+		{[]string{"-m", `"fix login"`}, []string{"fix login"}},
+		{[]string{"-am", "'wip'"}, []string{"wip"}},
+		{[]string{`-m"attached"`}, []string{"attached"}},
+		{[]string{"--message=release"}, []string{"release"}},
+		{[]string{"-m", "title", "-m", "body"}, []string{"title", "body"}},
+		{[]string{"--amend", "--no-edit"}, nil},
+		{[]string{"-m"}, nil},
+	}
+	for _, tt := range tests {
+		if got := commitMessages(tt.args); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("commitMessages(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestMedianLength(t *testing.T) {
+	tests := []struct {
+		lengths map[int]int
+		want    int
+	}{
+		{map[int]int{}, 0},
+		{map[int]int{12: 1}, 12},
+		{map[int]int{3: 1, 10: 1, 40: 1}, 10},
+		// upper median of an even count
+		{map[int]int{3: 2, 10: 2}, 10},
+		{map[int]int{5: 3, 80: 1}, 5},
+	}
+	for _, tt := range tests {
+		count := 0
+		for _, n := range tt.lengths {
+			count += n
+		}
+		if got := medianLength(tt.lengths, count); got != tt.want {
+			t.Errorf("medianLength(%v) = %d, want %d", tt.lengths, got, tt.want)
+		}
+	}
+}
+
+func TestGit(t *testing.T) {
+	stats := analyzeLines(t,
+		"git status",
+		"git status",
+		`git commit -m "add login form"`,
+		"git commit -am wip",
+		"git commit --amend --no-edit",
+		"git push",
+		"git push -uf origin main",
+		"git push --force-with-lease",
+		"git pull --rebase",
+		"git rebase main",
+		"git rebase --continue",
+		"git merge feature",
+		"git stash",
+		"git stash pop",
+		"git checkout -b feature",
+		"git checkout main",
+		"git switch main",
+		"git checkout main.go",
+		"git checkout -",
+		"ls",
+	)
+	git := stats.Git
+
+	if git.Commands != 19 || git.Commits != 3 || git.Amends != 1 {
+		t.Errorf("commands, commits, amends = %d %d %d, want 19 3 1", git.Commands, git.Commits, git.Amends)
+	}
+	if git.Pushes != 3 || git.ForcePushes != 2 {
+		t.Errorf("pushes = %d (%d forced), want 3 (2 forced)", git.Pushes, git.ForcePushes)
+	}
+	if git.Rebases != 2 || git.Merges != 1 {
+		t.Errorf("rebases, merges = %d %d, want 2 1", git.Rebases, git.Merges)
+	}
+	if git.Stashes != 1 || git.StashPops != 1 {
+		t.Errorf("stashes, pops = %d %d, want 1 1", git.Stashes, git.StashPops)
+	}
+	if git.StatusRatio != 2.0/3 {
+		t.Errorf("StatusRatio = %v, want 2/3", git.StatusRatio)
+	}
+
+	// files and "-" aren't branches
+	want := []CommandCount{{"main", 2}, {"feature", 1}}
+	if !reflect.DeepEqual(git.TopBranches, want) || git.BranchesCreated != 1 {
+		t.Errorf("branches = %+v (%d created), want %+v (1 created)", git.TopBranches, git.BranchesCreated, want)
+	}
+
+	if git.MessageCount != 2 || git.ShortestMessage != "wip" || git.LongestMessage != "add login form" {
+		t.Errorf("messages = %+v", git)
+	}
+	if git.LazyMessages != 1 || git.MedianMessageLen != 14 || git.AvgMessageLen != 8.5 {
+		t.Errorf("lazy %d, median %d, avg %v; want 1, 14, 8.5", git.LazyMessages, git.MedianMessageLen, git.AvgMessageLen)
+	}
+}
+
+func TestIsBranchName(t *testing.T) {
+	tests := []struct {
+		arg  string
+		want bool
+	}{
+		{"main", true},
+		{"feature/login", true},
+		{"fix-123", true},
+		{"release-2025", true},
+		{"main.go", false},
+		{"Makefile", false},
+		{"docker/Dockerfile", false},
+		{"src/api", false},
+		{"internal/ui", false},
+		{"./scripts", false},
+		{"docs/", false},
+		{"/etc/hosts", false},
+		{"HEAD", false},
+		{"HEAD~1", false},
+		{"HEAD^", false},
+		{"main~2", false},
+		{"@{-1}", false},
+		{"stash@{0}", false},
+		{"a1b2c3d", false},
+		{"9fceb02d0ae598e95dc970b74767f19372d61af8", false},
+		{"deadbeef", true}, // no digit, could be a branch
+		{"added", true},
+	}
+	for _, tt := range tests {
+		if got := isBranchName(tt.arg); got != tt.want {
+			t.Errorf("isBranchName(%q) = %v, want %v", tt.arg, got, tt.want)
+		}
+	}
+}
+
+func TestCommitMessagesRedacted(t *testing.T) {
+	stats := analyzeLines(t,
+		`git commit -m "rotate key api_key=abcd1234"`,
+		`git commit -m "ok"`,
+	)
+	if strings.Contains(stats.Git.LongestMessage, "abcd1234") {
+		t.Errorf("LongestMessage = %q, want the key masked", stats.Git.LongestMessage)
+	}
+	if stats.Git.ShortestMessage != "ok" {
+		t.Errorf("ShortestMessage = %q, want ok", stats.Git.ShortestMessage)
+	}
+}
+
+func TestLazyMessages(t *testing.T) {
+	g := newGitTracker()
+	for _, message := range []string{"fix", "Updates.", "ok", "stuff!", "refactor the parser", "  "} {
+		g.addMessage(message)
+	}
+	if g.git.MessageCount != 5 || g.git.LazyMessages != 4 {
+		t.Errorf("%d messages, %d lazy; want 5 and 4", g.git.MessageCount, g.git.LazyMessages)
+	}
+}
@@ -0,0 +1,46 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// testNow is when test histories are analyzed
+var testNow = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// analyzeLines analyzes zsh history lines with the default options
+func analyzeLines(t *testing.T, lines ...string) *Stats {
+	t.Helper()
+	return analyzeLinesWith(t, DefaultOptions(), lines...)
+}
+
+// analyzeLinesWith analyzes zsh history lines, timestamps in UTC
+func analyzeLinesWith(t *testing.T, opts Options, lines ...string) *Stats {
+	t.Helper()
+	scanner := parser.NewScanner(strings.NewReader(strings.Join(lines, "\n")+"\n"), "zsh")
+	scanner.SetLocation(time.UTC)
+	stats, err := AnalyzeStream(scanner, testNow, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return stats
+}
+
+// timed formats a zsh extended history line
+func timed(at time.Time, command string) string {
+	return fmt.Sprintf(": %d:0;%s", at.Unix(), command)
+}
+
+// at returns a time on a day of February 2025, UTC
+func at(day, hour, minute int) time.Time {
+	return time.Date(2025, 2, day, hour, minute, 0, 0, time.UTC)
+}
+
+// commandAt is an event for a timestamped ls
+func commandAt(ts time.Time) *Event {
+	return &Event{Cmd: &parser.Command{Command: "ls", Timestamp: ts, HasTime: true}, Base: "ls"}
+}
@@ -0,0 +1,162 @@
+package analyzer
+
+import (
+	"strings"
+)
+
+// infraStats covers kubernetes, terraform and cloud CLIs
+type InfraStats struct {
+	KubeCommands  int
+	TopContexts   []CommandCount // from --context, use-context and kubectx
+	TopNamespaces []CommandCount // from -n/--namespace and kubens
+	TopKubeVerbs  []CommandCount // get, apply, logs, ...
+
+	TerraformPlans    int
+	TerraformApplies  int
+	TerraformDestroys int
+	PlanApplyRatio    float64 // plans per apply
+
+	CloudCommands int
+	Providers     []CommandCount // aws, gcloud, az
+	CloudServices []CommandCount // "aws s3", "gcloud compute", ...
+}
+
+const topInfraCount = 5
+
+// cloud CLIs whose first argument names the service
+var cloudProviders = map[string]bool{"aws": true, "gcloud": true, "az": true}
+
+// infraTracker collects kube contexts, terraform runs and cloud services
+type infraTracker struct {
+	infra      InfraStats
+	contexts   *topCounts[string]
+	namespaces *topCounts[string]
+	verbs      map[string]int
+	providers  map[string]int
+	services   *topCounts[string]
+}
+
+func newInfraTracker() *infraTracker {
+	return &infraTracker{
+		contexts:   newTopCounts[string](maxTrackedKeys),
+		namespaces: newTopCounts[string](maxTrackedKeys),
+		verbs:      make(map[string]int),
+		providers:  make(map[string]int),
+		services:   newTopCounts[string](maxTrackedKeys),
+	}
+}
+
+func (t *infraTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	args := commandArgs(cmd, baseCmd)
+
+	switch baseCmd {
+	case "kubectl", "helm", "k9s":
+		t.infra.KubeCommands++
+		if context := flagValue(args, "--context", "--kube-context"); context != "" {
+			t.contexts.add(context)
+		}
+		if namespace := flagValue(args, "-n", "--namespace"); namespace != "" {
+			t.namespaces.add(namespace)
+		}
+		if baseCmd != "kubectl" {
+			return
+		}
+		verb := subcommandOf(args, infraValueFlags)
+		if verb == "config" {
+			if context := flagValue(args, "use-context"); context != "" {
+				t.contexts.add(context)
+			}
+		}
+		if verb != "" {
+			t.verbs[verb]++
+		}
+	case "kubectx":
+		t.infra.KubeCommands++
+		if context := firstArg(args); context != "" && context != "-" {
+			t.contexts.add(context)
+		}
+	case "kubens":
+		t.infra.KubeCommands++
+		if namespace := firstArg(args); namespace != "" && namespace != "-" {
+			t.namespaces.add(namespace)
+		}
+	case "terraform", "tofu", "terragrunt":
+		switch subcommandOf(args, infraValueFlags) {
+		case "plan":
+			t.infra.TerraformPlans++
+		case "apply":
+			t.infra.TerraformApplies++
+		case "destroy":
+			t.infra.TerraformDestroys++
+		}
+	}
+
+	if CategoryOf(baseCmd) == "Cloud" {
+		t.infra.CloudCommands++
+	}
+	if cloudProviders[baseCmd] {
+		t.providers[baseCmd]++
+		if service := subcommandOf(args, infraValueFlags); service != "" && !strings.ContainsAny(service, "/=") {
+			t.services.add(baseCmd + " " + service)
+		}
+	}
+}
+
+func (t *infraTracker) Finish(stats *Stats) {
+	t.infra.TopContexts = topN(t.contexts.snapshot(stats), topInfraCount)
+	t.infra.TopNamespaces = topN(t.namespaces.snapshot(stats), topInfraCount)
+	t.infra.TopKubeVerbs = topN(t.verbs, topInfraCount)
+	t.infra.Providers = topN(t.providers, topInfraCount)
+	t.infra.CloudServices = topN(t.services.snapshot(stats), topInfraCount)
+	if t.infra.TerraformApplies > 0 {
+		t.infra.PlanApplyRatio = float64(t.infra.TerraformPlans) / float64(t.infra.TerraformApplies)
+	}
+	stats.Infra = t.infra
+}
+
+// options of kubectl, helm, terraform and the cloud CLIs that take a value
+var infraValueFlags = map[string]bool{
+	"-n": true, "--namespace": true, "--context": true, "--kube-context": true,
+	"--kubeconfig": true, "-l": true, "--selector": true, "-o": true, "--output": true,
+	"--profile": true, "--region": true, "--project": true, "--subscription": true,
+	"-chdir": true,
+}
+
+// subcommandOf returns the first positional argument, skipping options and
+// the values of options listed in valueFlags
+func subcommandOf(args []string, valueFlags map[string]bool) string {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isShellOperator(arg) {
+			return ""
+		}
+		if valueFlags[arg] {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
+
+// flagValue returns the value of the first matching option, whether given
+// as "--name value" or "--name=value"
+func flagValue(args []string, names ...string) string {
+	for i, arg := range args {
+		if isShellOperator(arg) {
+			return ""
+		}
+		for _, name := range names {
+			if arg == name && i+1 < len(args) {
+				return unquote(args[i+1])
+			}
+			if value, ok := strings.CutPrefix(arg, name+"="); ok {
+				return unquote(value)
+			}
+		}
+	}
+	return ""
+}
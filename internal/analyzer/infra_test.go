@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFlagValue(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{"get pods -n prod", "prod"},
+		{"get pods --namespace=staging", "staging"},
+		{`logs api --namespace "dev"`, "dev"},
+		{"get pods | grep -n x", ""},
+		{"get pods -n", ""},
+	}
+	for _, tt := range tests {
+		if got := flagValue(strings.Fields(tt.args), "-n", "--namespace"); got != tt.want {
+			t.Errorf("flagValue(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestSubcommandOf(t *testing.T) {
+	tests := []struct {
+		args string
+		want string
+	}{
+		{"-n prod get pods", "get"},
+		{"--context=prod apply -f x.yaml", "apply"},
+		{"-chdir=infra plan", "plan"},
+		{"-chdir infra plan", "plan"},
+		{"--profile dev s3 ls", "s3"},
+		{"--help", ""},
+	}
+	for _, tt := range tests {
+		if got := subcommandOf(strings.Fields(tt.args), infraValueFlags); got != tt.want {
+			t.Errorf("subcommandOf(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestInfra(t *testing.T) {
+	stats := analyzeLines(t,
+		"kubectl get pods -n prod",
+		"kubectl --context prod-eu logs api -n prod",
+		"kubectl config use-context staging",
+		"kubectx prod-eu",
+		"kubectx -",
+		"kubens kube-system",
+		"helm upgrade web ./chart --namespace prod",
+		"terraform plan",
+		"terraform plan",
+		"terraform -chdir=infra apply",
+		"tofu destroy",
+		"aws s3 ls",
+		"aws --profile dev s3 cp a b",
+		"gcloud compute instances list",
+		"ls",
+	)
+	infra := stats.Infra
+
+	if infra.KubeCommands != 7 {
+		t.Errorf("KubeCommands = %d, want 7", infra.KubeCommands)
+	}
+	if want := []CommandCount{{"prod-eu", 2}, {"staging", 1}}; !reflect.DeepEqual(infra.TopContexts, want) {
+		t.Errorf("TopContexts = %+v, want %+v", infra.TopContexts, want)
+	}
+	if want := []CommandCount{{"prod", 3}, {"kube-system", 1}}; !reflect.DeepEqual(infra.TopNamespaces, want) {
+		t.Errorf("TopNamespaces = %+v, want %+v", infra.TopNamespaces, want)
+	}
+	if want := []CommandCount{{"config", 1}, {"get", 1}, {"logs", 1}}; !reflect.DeepEqual(infra.TopKubeVerbs, want) {
+		t.Errorf("TopKubeVerbs = %+v, want %+v", infra.TopKubeVerbs, want)
+	}
+
+	if infra.TerraformPlans != 2 || infra.TerraformApplies != 1 || infra.TerraformDestroys != 1 || infra.PlanApplyRatio != 2 {
+		t.Errorf("terraform = %d plans, %d applies, %d destroys, ratio %v; want 2, 1, 1, 2",
+			infra.TerraformPlans, infra.TerraformApplies, infra.TerraformDestroys, infra.PlanApplyRatio)
+	}
+
+	if infra.CloudCommands != 7 {
+		t.Errorf("CloudCommands = %d, want 7", infra.CloudCommands)
+	}
+	if want := []CommandCount{{"aws", 2}, {"gcloud", 1}}; !reflect.DeepEqual(infra.Providers, want) {
+		t.Errorf("Providers = %+v, want %+v", infra.Providers, want)
+	}
+	if want := []CommandCount{{"aws s3", 2}, {"gcloud compute", 1}}; !reflect.DeepEqual(infra.CloudServices, want) {
+		t.Errorf("CloudServices = %+v, want %+v", infra.CloudServices, want)
+	}
+}
@@ -0,0 +1,175 @@
+package analyzer
+
+import (
+	"math"
+	"path"
+	"sort"
+	"strings"
+)
+
+// languageCount is how many commands were attributed to a language
+type LanguageCount struct {
+	Language string
+	Commands int
+	Pct      float64 // of all language-attributed commands
+}
+
+const (
+	topLanguageCount = 8
+	// languages with fewer commands don't count towards the polyglot score
+	minLanguageCommands = 3
+)
+
+// toolchains and interpreters that imply a language
+var toolLanguages = map[string]string{
+	"go": "Go", "gofmt": "Go", "golangci-lint": "Go", "dlv": "Go",
+	"cargo": "Rust", "rustc": "Rust", "rustup": "Rust", "clippy-driver": "Rust",
+	"python": "Python", "python3": "Python", "pip": "Python", "pip3": "Python", "pytest": "Python",
+	"uv": "Python", "poetry": "Python", "pipx": "Python", "ipython": "Python", "jupyter": "Python",
+	"mypy": "Python", "ruff": "Python", "black": "Python", "conda": "Python",
+	"node": "JavaScript", "npm": "JavaScript", "npx": "JavaScript", "yarn": "JavaScript",
+	"pnpm": "JavaScript", "bun": "JavaScript", "deno": "TypeScript", "tsc": "TypeScript",
+	"ts-node": "TypeScript", "tsx": "TypeScript",
+	"java": "Java", "javac": "Java", "mvn": "Java", "gradle": "Java", "./gradlew": "Java",
+	"kotlin": "Kotlin", "kotlinc": "Kotlin",
+	"gcc": "C", "cc": "C", "clang": "C",
+	"g++": "C++", "clang++": "C++",
+	"ruby": "Ruby", "gem": "Ruby", "bundle": "Ruby", "rails": "Ruby", "rake": "Ruby", "irb": "Ruby",
+	"php": "PHP", "composer": "PHP", "artisan": "PHP",
+	"swift": "Swift", "swiftc": "Swift", "xcodebuild": "Swift",
+	"dotnet": "C#",
+	"elixir": "Elixir", "mix": "Elixir", "iex": "Elixir",
+	"ghc": "Haskell", "ghci": "Haskell", "stack": "Haskell", "cabal": "Haskell",
+	"zig": "Zig", "lua": "Lua", "Rscript": "R", "perl": "Perl",
+	"scala": "Scala", "sbt": "Scala", "dart": "Dart", "flutter": "Dart",
+}
+
+// file extensions in editor, compiler and interpreter arguments (vim main.go,
+// python script.py). git add main.go or rm *.py says nothing about the
+// language someone works in
+var extensionLanguages = map[string]string{
+	".go": "Go", ".rs": "Rust", ".py": "Python", ".ipynb": "Python",
+	".js": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript", ".jsx": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript",
+	".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin",
+	".c": "C", ".h": "C", ".cpp": "C++", ".cc": "C++", ".cxx": "C++", ".hpp": "C++",
+	".rb": "Ruby", ".php": "PHP", ".swift": "Swift", ".cs": "C#",
+	".ex": "Elixir", ".exs": "Elixir", ".hs": "Haskell", ".zig": "Zig", ".lua": "Lua",
+	".r": "R", ".pl": "Perl", ".scala": "Scala", ".dart": "Dart",
+	".sh": "Shell", ".bash": "Shell", ".zsh": "Shell", ".sql": "SQL",
+}
+
+// build tools that belong to whatever language the session was just using
+var languageNeutralBuilds = map[string]bool{
+	"make": true, "cmake": true, "ninja": true, "just": true, "bazel": true, "meson": true,
+}
+
+// languageTracker attributes commands to programming languages
+type languageTracker struct {
+	counts map[string]int
+	last   string // language most recently seen in this session
+}
+
+func newLanguageTracker() *languageTracker {
+	return &languageTracker{counts: make(map[string]int)}
+}
+
+func (l *languageTracker) Add(e *Event) {
+	cmd, baseCmd, newSession := e.Cmd, e.Base, e.NewSession
+	if newSession {
+		l.last = ""
+	}
+
+	var languages []string
+	if editors[baseCmd] || toolLanguages[baseCmd] != "" {
+		languages = fileLanguages(commandArgs(cmd, baseCmd))
+	}
+	if len(languages) == 0 {
+		switch {
+		case toolLanguages[baseCmd] != "":
+			languages = []string{toolLanguages[baseCmd]}
+		case languageNeutralBuilds[baseCmd] && l.last != "":
+			languages = []string{l.last}
+		}
+	}
+
+	for _, language := range languages {
+		l.counts[language]++
+	}
+	if len(languages) > 0 {
+		l.last = languages[0]
+	}
+}
+
+func (l *languageTracker) Finish(stats *Stats) {
+	total := 0
+	for _, count := range l.counts {
+		total += count
+	}
+	if total == 0 {
+		return
+	}
+
+	// effective number of languages: exp of the Shannon entropy of the split
+	entropy := 0.0
+	for language, count := range l.counts {
+		if count >= minLanguageCommands {
+			stats.LanguageCount++
+		}
+		p := float64(count) / float64(total)
+		entropy -= p * math.Log(p)
+		stats.Languages = append(stats.Languages, LanguageCount{
+			Language: language,
+			Commands: count,
+			Pct:      p * 100,
+		})
+	}
+	stats.PolyglotScore = math.Exp(entropy)
+
+	sort.Slice(stats.Languages, func(i, j int) bool {
+		a, b := stats.Languages[i], stats.Languages[j]
+		if a.Commands != b.Commands {
+			return a.Commands > b.Commands
+		}
+		return a.Language < b.Language
+	})
+	if len(stats.Languages) > topLanguageCount {
+		stats.Languages = stats.Languages[:topLanguageCount]
+	}
+}
+
+// fileLanguages returns the languages of source files named in args, in
+// order of first appearance
+func fileLanguages(args []string) []string {
+	var languages []string
+	seen := make(map[string]bool)
+	for _, arg := range args {
+		if isShellOperator(arg) {
+			break
+		}
+		arg = unquote(arg)
+		if strings.HasPrefix(arg, "-") || strings.Contains(arg, "://") {
+			continue
+		}
+		language := extensionLanguages[strings.ToLower(path.Ext(arg))]
+		if language != "" && !seen[language] {
+			seen[language] = true
+			languages = append(languages, language)
+		}
+	}
+	return languages
+}
+
+// getPolyglotLevel returns a fun label for the polyglot score
+func GetPolyglotLevel(score float64) string {
+	switch {
+	case score < 1.5:
+		return "Monoglot"
+	case score < 2.5:
+		return "Bilingual"
+	case score < 4:
+		return "Polyglot"
+	default:
+		return "Babel Tower"
+	}
+}
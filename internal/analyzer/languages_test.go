@@ -0,0 +1,70 @@
+package analyzer
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFileLanguages(t *testing.T) {
+	tests := []struct {
+		args string
+		want []string
+	}{
+		{"main.go util.go", []string{"Go"}},
+		{"app.ts Main.PY", []string{"TypeScript", "Python"}},
+		{"--config=x.rb README.md", nil},
+		{"https://example.com/x.js", nil},
+		{"a.c | wc -l b.rs", []string{"C"}},
+	}
+	for _, tt := range tests {
+		if got := fileLanguages(strings.Fields(tt.args)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("fileLanguages(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(at(3, 9, 0), "go test ./..."),
+		timed(at(3, 9, 1), "vim main.go"),
+		timed(at(3, 9, 2), "make"), // follows the session's Go
+		timed(at(3, 9, 3), "cargo build"),
+		timed(at(3, 9, 4), "make"), // now Rust
+		timed(at(3, 9, 5), "python3 tool.py"),
+		timed(at(3, 9, 6), "pytest"),
+		timed(at(3, 9, 7), "python -c 1"),
+		// files handled by other commands don't count
+		timed(at(3, 9, 8), "git add main.go"),
+		timed(at(3, 9, 9), "rm *.py"),
+		timed(at(3, 9, 10), "cat foo.rs"),
+		// a new session forgets the last language
+		timed(at(5, 9, 0), "make"),
+		timed(at(5, 9, 1), "ls"),
+	)
+
+	want := []LanguageCount{
+		{Language: "Go", Commands: 3, Pct: 37.5},
+		{Language: "Python", Commands: 3, Pct: 37.5},
+		{Language: "Rust", Commands: 2, Pct: 25},
+	}
+	if !reflect.DeepEqual(stats.Languages, want) {
+		t.Errorf("Languages = %+v, want %+v", stats.Languages, want)
+	}
+	// Rust has too few commands to be in regular use
+	if stats.LanguageCount != 2 {
+		t.Errorf("LanguageCount = %d, want 2", stats.LanguageCount)
+	}
+	entropy := -(2*0.375*math.Log(0.375) + 0.25*math.Log(0.25))
+	if math.Abs(stats.PolyglotScore-math.Exp(entropy)) > 1e-9 {
+		t.Errorf("PolyglotScore = %v, want %v", stats.PolyglotScore, math.Exp(entropy))
+	}
+}
+
+func TestPolyglotScoreOfOneLanguage(t *testing.T) {
+	stats := analyzeLines(t, "go build", "go test", "go vet")
+	if stats.PolyglotScore != 1 || GetPolyglotLevel(stats.PolyglotScore) != "Monoglot" {
+		t.Errorf("PolyglotScore = %v (%s), want 1 (Monoglot)", stats.PolyglotScore, GetPolyglotLevel(stats.PolyglotScore))
+	}
+}
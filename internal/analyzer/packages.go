@@ -0,0 +1,263 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// packageEvent is one package installed or removed
+type PackageEvent struct {
+	Time      time.Time `json:"time,omitzero"` // zero without timestamps
+	Manager   string    `json:"manager"`
+	Ecosystem string    `json:"ecosystem"`
+	Package   string    `json:"package"`
+	Action    string    `json:"action"` // "install" or "remove"
+}
+
+// packageCount is how often a package was installed
+type PackageCount struct {
+	Package   string
+	Ecosystem string
+	Count     int
+}
+
+// ecosystemCount tallies install events per ecosystem
+type EcosystemCount struct {
+	Ecosystem string
+	Installs  int
+	Removes   int
+}
+
+// packageStats is the package ledger
+type PackageStats struct {
+	Installs     int
+	Removes      int
+	Ledger       []PackageEvent // every event, in history order
+	TopInstalled []PackageCount
+	Ecosystems   []EcosystemCount
+}
+
+const topPackageCount = 8
+
+// packageManager describes how a package manager spells install and remove
+type packageManager struct {
+	ecosystem string
+	install   []string
+	remove    []string
+}
+
+var packageManagers = map[string]packageManager{
+	"npm":     {"npm", []string{"install", "i", "add"}, []string{"uninstall", "remove", "rm", "un", "r"}},
+	"yarn":    {"npm", []string{"add"}, []string{"remove"}},
+	"pnpm":    {"npm", []string{"add", "install", "i"}, []string{"remove", "rm", "uninstall"}},
+	"bun":     {"npm", []string{"add", "install", "i"}, []string{"remove", "rm"}},
+	"pip":     {"pypi", []string{"install"}, []string{"uninstall"}},
+	"pip3":    {"pypi", []string{"install"}, []string{"uninstall"}},
+	"uv":      {"pypi", []string{"add"}, []string{"remove"}},
+	"poetry":  {"pypi", []string{"add"}, []string{"remove"}},
+	"pipx":    {"pypi", []string{"install"}, []string{"uninstall"}},
+	"brew":    {"homebrew", []string{"install", "reinstall"}, []string{"uninstall", "remove", "rm"}},
+	"apt":     {"apt", []string{"install"}, []string{"remove", "purge"}},
+	"apt-get": {"apt", []string{"install"}, []string{"remove", "purge"}},
+	"yum":     {"rpm", []string{"install"}, []string{"remove", "erase"}},
+	"dnf":     {"rpm", []string{"install"}, []string{"remove", "erase"}},
+	"cargo":   {"cargo", []string{"install", "add"}, []string{"uninstall", "remove", "rm"}},
+	"go":      {"go", []string{"install", "get"}, nil},
+	"gem":     {"rubygems", []string{"install"}, []string{"uninstall"}},
+}
+
+// options whose value is not a package (pip install -r requirements.txt)
+var packageValueFlags = map[string]bool{
+	"-r": true, "--requirement": true, "-c": true, "--constraint": true, "-e": true,
+	"--editable": true, "-i": true, "--index-url": true, "--extra-index-url": true,
+	"-t": true, "--target": true, "--prefix": true, "--registry": true, "--python": true,
+	"-p": true, "--features": true, "--git": true, "--path": true, "--version": true,
+}
+
+// packageTracker builds the install/remove ledger. the ledger keeps every
+// event, so it is the one result that grows with the history; it is only
+// kept when asked for, and its names are interned so events don't pin the
+// command lines they came from
+type packageTracker struct {
+	stats      PackageStats
+	keepLedger bool
+	names      map[string]string
+	installs   *topCounts[[2]string] // package, ecosystem -> installs
+	ecosystems map[string]*EcosystemCount
+}
+
+func newPackageTracker(keepLedger bool) *packageTracker {
+	return &packageTracker{
+		keepLedger: keepLedger,
+		names:      make(map[string]string),
+		installs:   newTopCounts[[2]string](maxTrackedKeys),
+		ecosystems: make(map[string]*EcosystemCount),
+	}
+}
+
+func (p *packageTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	manager := baseCmd
+	args := commandArgs(cmd, baseCmd)
+
+	// python -m pip install, uv pip install
+	if (baseCmd == "python" || baseCmd == "python3") && len(args) > 1 && args[0] == "-m" {
+		manager, args = args[1], args[2:]
+	} else if baseCmd == "uv" && len(args) > 0 && args[0] == "pip" {
+		manager, args = "pip", args[1:]
+	}
+
+	var action string
+	var packages []string
+	if manager == "pacman" || manager == "yay" || manager == "paru" {
+		action, packages = pacmanEvent(args)
+		manager = "pacman"
+	} else {
+		pm, ok := packageManagers[manager]
+		if !ok {
+			return
+		}
+		sub := subcommandOf(args, nil)
+		switch {
+		case containsString(pm.install, sub):
+			action = "install"
+		case containsString(pm.remove, sub):
+			action = "remove"
+		default:
+			return
+		}
+		for i, arg := range args {
+			if arg == sub {
+				packages = positionalArgs(args[i+1:], packageValueFlags)
+				break
+			}
+		}
+	}
+
+	ecosystem := "arch"
+	if pm, ok := packageManagers[manager]; ok {
+		ecosystem = pm.ecosystem
+	}
+	for _, pkg := range packages {
+		name := packageName(pkg, ecosystem)
+		if name == "" {
+			continue
+		}
+
+		eco := p.ecosystems[ecosystem]
+		if eco == nil {
+			eco = &EcosystemCount{Ecosystem: ecosystem}
+			p.ecosystems[ecosystem] = eco
+		}
+		if action == "install" {
+			p.stats.Installs++
+			eco.Installs++
+			p.installs.add([2]string{name, ecosystem})
+		} else {
+			p.stats.Removes++
+			eco.Removes++
+		}
+
+		if !p.keepLedger {
+			continue
+		}
+		if interned, ok := p.names[name]; ok {
+			name = interned
+		} else {
+			name = strings.Clone(name)
+			p.names[name] = name
+		}
+		event := PackageEvent{Manager: manager, Ecosystem: ecosystem, Package: name, Action: action}
+		if cmd.HasTime {
+			event.Time = cmd.Timestamp
+		}
+		p.stats.Ledger = append(p.stats.Ledger, event)
+	}
+}
+
+func (p *packageTracker) Finish(stats *Stats) {
+	for key, count := range p.installs.snapshot(stats) {
+		p.stats.TopInstalled = append(p.stats.TopInstalled, PackageCount{Package: key[0], Ecosystem: key[1], Count: count})
+	}
+	sort.Slice(p.stats.TopInstalled, func(i, j int) bool {
+		a, b := p.stats.TopInstalled[i], p.stats.TopInstalled[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.Ecosystem < b.Ecosystem
+	})
+	if len(p.stats.TopInstalled) > topPackageCount {
+		p.stats.TopInstalled = p.stats.TopInstalled[:topPackageCount]
+	}
+
+	for _, eco := range p.ecosystems {
+		p.stats.Ecosystems = append(p.stats.Ecosystems, *eco)
+	}
+	sort.Slice(p.stats.Ecosystems, func(i, j int) bool {
+		a, b := p.stats.Ecosystems[i], p.stats.Ecosystems[j]
+		if a.Installs+a.Removes != b.Installs+b.Removes {
+			return a.Installs+a.Removes > b.Installs+b.Removes
+		}
+		return a.Ecosystem < b.Ecosystem
+	})
+
+	stats.Packages = p.stats
+}
+
+// pacmanEvent reads pacman-style operations: -S installs, -R removes. search,
+// info and clean variants of -S (-Ss, -Si, -Sc) are not installs
+func pacmanEvent(args []string) (string, []string) {
+	action := ""
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-S") && !strings.ContainsAny(arg[2:], "scilgq") {
+			action = "install"
+		}
+		if strings.HasPrefix(arg, "-R") {
+			action = "remove"
+		}
+	}
+	if action == "" {
+		return "", nil
+	}
+	return action, positionalArgs(args, nil)
+}
+
+// packageName strips versions and extras (lodash@4, requests==2.31, black[jupyter])
+func packageName(pkg, ecosystem string) string {
+	pkg = unquote(pkg)
+	if pkg == "" || pkg == "." || strings.HasPrefix(pkg, "./") || strings.HasPrefix(pkg, "/") ||
+		strings.HasSuffix(pkg, ".txt") || strings.Contains(pkg, "://") {
+		return ""
+	}
+
+	switch ecosystem {
+	case "npm":
+		// keep the scope of @types/node@20
+		if i := strings.LastIndex(pkg, "@"); i > 0 {
+			pkg = pkg[:i]
+		}
+	case "go":
+		pkg, _, _ = strings.Cut(pkg, "@")
+	case "pypi":
+		if i := strings.IndexAny(pkg, "=<>~![;"); i >= 0 {
+			pkg = pkg[:i]
+		}
+		pkg = strings.ToLower(pkg)
+	default:
+		pkg, _, _ = strings.Cut(pkg, "@")
+	}
+	return pkg
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
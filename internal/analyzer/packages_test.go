@@ -0,0 +1,113 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPackageName(t *testing.T) {
+	tests := []struct {
+		pkg, ecosystem string
+		want           string
+	}{
+		{"lodash@4.17.21", "npm", "lodash"},
+		{"@types/node@20", "npm", "@types/node"},
+		{"@scope/pkg", "npm", "@scope/pkg"},
+		{"Requests==2.31", "pypi", "requests"},
+		{"black[jupyter]", "pypi", "black"},
+		{`"django>=4"`, "pypi", "django"},
+		{"golang.org/x/tools/gopls@latest", "go", "golang.org/x/tools/gopls"},
+		{"ripgrep", "cargo", "ripgrep"},
+		{"requirements.txt", "pypi", ""},
+		{"./local-pkg", "npm", ""},
+		{"git+https://github.com/x/y", "pypi", ""},
+		{".", "pypi", ""},
+	}
+	for _, tt := range tests {
+		if got := packageName(tt.pkg, tt.ecosystem); got != tt.want {
+			t.Errorf("packageName(%q, %s) = %q, want %q", tt.pkg, tt.ecosystem, got, tt.want)
+		}
+	}
+}
+
+func TestPacmanEvent(t *testing.T) {
+	tests := []struct {
+		args     string
+		action   string
+		packages []string
+	}{
+		{"-S neovim git", "install", []string{"neovim", "git"}},
+		{"-Syu", "install", nil},
+		{"-Rns firefox", "remove", []string{"firefox"}},
+		{"-Ss vim", "", nil},
+		{"-Si vim", "", nil},
+		{"-Qi vim", "", nil},
+	}
+	for _, tt := range tests {
+		action, packages := pacmanEvent(strings.Fields(tt.args))
+		if action != tt.action || !reflect.DeepEqual(packages, tt.packages) {
+			t.Errorf("pacmanEvent(%q) = %q %q, want %q %q", tt.args, action, packages, tt.action, tt.packages)
+		}
+	}
+}
+
+func TestPackages(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(at(3, 9, 0), "npm install lodash@4 @types/node"),
+		timed(at(3, 9, 5), "pip install -r requirements.txt requests==2.31"),
+		timed(at(3, 9, 10), "python3 -m pip install requests"),
+		timed(at(3, 9, 15), "uv pip install Requests"),
+		timed(at(3, 9, 20), "brew install ripgrep"),
+		timed(at(3, 9, 25), "npm uninstall lodash"),
+		timed(at(3, 9, 30), "sudo pacman -S neovim"),
+		timed(at(3, 9, 35), "npm run build"),
+		timed(at(3, 9, 40), "brew search jq"),
+	)
+	packages := stats.Packages
+
+	if packages.Installs != 7 || packages.Removes != 1 {
+		t.Errorf("%d installs, %d removes; want 7 and 1", packages.Installs, packages.Removes)
+	}
+	if top := packages.TopInstalled[0]; top != (PackageCount{Package: "requests", Ecosystem: "pypi", Count: 3}) {
+		t.Errorf("top install = %+v, want requests x3", top)
+	}
+	if len(packages.TopInstalled) != 5 {
+		t.Errorf("TopInstalled = %+v, want 5 packages", packages.TopInstalled)
+	}
+
+	want := []EcosystemCount{
+		{Ecosystem: "npm", Installs: 2, Removes: 1},
+		{Ecosystem: "pypi", Installs: 3},
+		{Ecosystem: "arch", Installs: 1},
+		{Ecosystem: "homebrew", Installs: 1},
+	}
+	if !reflect.DeepEqual(packages.Ecosystems, want) {
+		t.Errorf("Ecosystems = %+v, want %+v", packages.Ecosystems, want)
+	}
+
+	if len(packages.Ledger) != 8 {
+		t.Fatalf("ledger has %d events, want 8", len(packages.Ledger))
+	}
+	first := packages.Ledger[0]
+	if first.Package != "lodash" || first.Manager != "npm" || first.Action != "install" || !first.Time.Equal(at(3, 9, 0)) {
+		t.Errorf("first ledger event = %+v", first)
+	}
+	if last := packages.Ledger[7]; last.Manager != "pacman" || last.Ecosystem != "arch" || last.Package != "neovim" {
+		t.Errorf("last ledger event = %+v", last)
+	}
+}
+
+func TestSkipLedger(t *testing.T) {
+	opts := DefaultOptions()
+	opts.SkipLedger = true
+	stats := analyzeLinesWith(t, opts, "brew install jq", "brew install jq")
+
+	if stats.Packages.Ledger != nil {
+		t.Errorf("Ledger = %+v, want none", stats.Packages.Ledger)
+	}
+	// the summaries don't need the ledger
+	if stats.Packages.Installs != 2 || stats.Packages.TopInstalled[0].Count != 2 {
+		t.Errorf("Packages = %+v, want 2 installs of jq", stats.Packages)
+	}
+}
@@ -0,0 +1,188 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// oneLiner is a memorable complex command line, redacted for sharing
+type OneLiner struct {
+	Command string
+	Stages  int // stages of its longest pipeline
+	Score   int
+	Count   int
+}
+
+const (
+	topPipeTargetCount = 6
+	hallOfFameCount    = 5
+	// pipelines with more stages are counted together in PipeDepths
+	maxPipeDepthBucket = 5
+	// distinct one-liners kept as hall of fame candidates before pruning
+	maxOneLiners = 1024
+)
+
+var (
+	secretPattern = regexp.MustCompile(`(?i)((?:token|secret|passw(?:or)?d|pwd|api[_-]?key)[A-Za-z_]*["']?\s*[=: ]\s*["']?)[^\s"'&|;]+`)
+	headerPattern = regexp.MustCompile(`(?i)((?:authorization|x-api-key|cookie):\s*)[^"'\n]+`)
+	bearerPattern = regexp.MustCompile(`(?i)(bearer\s+)[^\s"']+`)
+	urlPattern    = regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^\s"'|;&)]+`)
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	ipPattern     = regexp.MustCompile(`\b\d{1,3}(?:\.\d{1,3}){3}\b`)
+	homePattern   = regexp.MustCompile(`/(?:Users|home)/[^/\s"']+`)
+	// long opaque strings are most likely keys or hashes
+	opaquePattern = regexp.MustCompile(`\b[A-Za-z0-9_\-+/=]{32,}\b`)
+)
+
+// pipeTracker measures pipelines and collects the most complex one-liners
+type pipeTracker struct {
+	targets   *topCounts[string]
+	depths    [maxPipeDepthBucket + 1]int // index = stages, last bucket = more
+	stages    int
+	maxDepth  int
+	longest   string
+	oneLiners map[string]*OneLiner // raw command line -> candidate
+	pruned    bool                 // candidates were dropped, so counts may be low
+}
+
+func newPipeTracker() *pipeTracker {
+	return &pipeTracker{
+		targets:   newTopCounts[string](maxTrackedKeys),
+		oneLiners: make(map[string]*OneLiner),
+	}
+}
+
+func (p *pipeTracker) Add(e *Event) {
+	cmd, pipelines := e.Cmd, e.Pipelines
+
+	depth := 0
+	for _, stages := range pipelines {
+		if len(stages) > depth {
+			depth = len(stages)
+		}
+		// the commands data gets piped into
+		for _, stage := range stages[1:] {
+			if target := parser.ParseStage(stage); target != nil {
+				p.targets.add(parser.GetBaseCommand(target))
+			}
+		}
+	}
+	if depth < 2 {
+		return
+	}
+
+	p.stages += depth
+	p.depths[min(depth, maxPipeDepthBucket)]++
+	if depth > p.maxDepth || (depth == p.maxDepth && len(cmd.Raw) > len(p.longest)) {
+		p.maxDepth = depth
+		p.longest = cmd.Raw
+	}
+
+	// redaction is expensive, so only the survivors are redacted in Finish
+	if one := p.oneLiners[cmd.Raw]; one != nil {
+		one.Count++
+		return
+	}
+	p.oneLiners[cmd.Raw] = &OneLiner{
+		Command: cmd.Raw,
+		Stages:  depth,
+		Score:   complexity(cmd.Raw, pipelines, depth),
+		Count:   1,
+	}
+	if len(p.oneLiners) > maxOneLiners {
+		p.pruneOneLiners()
+	}
+}
+
+// pruneOneLiners keeps only the highest scoring quarter of the candidates,
+// so a history full of distinct pipelines doesn't grow the map forever
+func (p *pipeTracker) pruneOneLiners() {
+	p.pruned = true
+	ranked := make([]*OneLiner, 0, len(p.oneLiners))
+	for _, one := range p.oneLiners {
+		ranked = append(ranked, one)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].Command < ranked[j].Command
+	})
+	for _, one := range ranked[maxOneLiners/4:] {
+		delete(p.oneLiners, one.Command)
+	}
+}
+
+func (p *pipeTracker) Finish(stats *Stats) {
+	for _, count := range p.depths {
+		stats.PipeCount += count
+	}
+	if stats.PipeCount == 0 {
+		return
+	}
+	if p.pruned {
+		stats.Approximate = true
+	}
+	stats.PipePct = float64(stats.PipeCount) / float64(stats.TotalCommands) * 100
+
+	stats.AvgPipeDepth = float64(p.stages) / float64(stats.PipeCount)
+	stats.MaxPipeDepth = p.maxDepth
+	stats.LongestPipeline = Redact(p.longest)
+	stats.PipeDepths = p.depths[2:]
+	stats.PipeTargets = topN(p.targets.snapshot(stats), topPipeTargetCount)
+
+	// lines that only differ in redacted secrets count as one
+	redacted := make(map[string]*OneLiner)
+	for _, one := range p.oneLiners {
+		command := Redact(one.Command)
+		if same := redacted[command]; same != nil {
+			same.Count += one.Count
+			same.Score = max(same.Score, one.Score)
+			same.Stages = max(same.Stages, one.Stages)
+			continue
+		}
+		merged := *one
+		merged.Command = command
+		redacted[command] = &merged
+	}
+	for _, one := range redacted {
+		stats.HallOfFame = append(stats.HallOfFame, *one)
+	}
+	sort.Slice(stats.HallOfFame, func(i, j int) bool {
+		a, b := stats.HallOfFame[i], stats.HallOfFame[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		return a.Command < b.Command
+	})
+	if len(stats.HallOfFame) > hallOfFameCount {
+		stats.HallOfFame = stats.HallOfFame[:hallOfFameCount]
+	}
+}
+
+// complexity scores a command line: pipe stages weigh most, then chained
+// pipelines, subshells and sheer length
+func complexity(raw string, pipelines [][]string, depth int) int {
+	score := depth * 3
+	score += (len(pipelines) - 1) * 2
+	score += strings.Count(raw, "$(") + strings.Count(raw, "`")/2
+	score += len(raw) / 40
+	return score
+}
+
+// redact masks secrets, URLs, emails, IPs, home directories and long opaque
+// strings so a command line can be shown or shared
+func Redact(line string) string {
+	line = headerPattern.ReplaceAllString(line, "${1}***")
+	line = bearerPattern.ReplaceAllString(line, "${1}***")
+	line = secretPattern.ReplaceAllString(line, "${1}***")
+	line = urlPattern.ReplaceAllString(line, "<url>")
+	line = emailPattern.ReplaceAllString(line, "<email>")
+	line = ipPattern.ReplaceAllString(line, "<ip>")
+	line = homePattern.ReplaceAllString(line, "~")
+	line = opaquePattern.ReplaceAllString(line, "***")
+	return line
+}
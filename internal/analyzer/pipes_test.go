@@ -0,0 +1,119 @@
+package analyzer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"export API_KEY=abc123", "export API_KEY=***"},
+		{`mysql --password="hunter2" db`, `mysql --password="***" db`},
+		{`curl -H "Authorization: Bearer xyz" https://api.example.com/v1`, `curl -H "Authorization: ***" <url>`},
+		{"git clone https://github.com/user/repo", "git clone <url>"},
+		{"mail -s hi anna@example.com", "mail -s hi <email>"},
+		{"ping 10.0.0.12", "ping <ip>"},
+		{"cat /Users/anna/notes.txt", "cat ~/notes.txt"},
+		{"echo " + strings.Repeat("a1", 20), "echo ***"},
+		{"ls -la | grep go", "ls -la | grep go"},
+	}
+	for _, tt := range tests {
+		if got := Redact(tt.line); got != tt.want {
+			t.Errorf("Redact(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestComplexity(t *testing.T) {
+	score := func(line string) int {
+		pipelines := parser.SplitPipelines(line)
+		depth := 0
+		for _, stages := range pipelines {
+			depth = max(depth, len(stages))
+		}
+		return complexity(line, pipelines, depth)
+	}
+
+	if got := score("a | b"); got != 6 {
+		t.Errorf("complexity(a | b) = %d, want 6", got)
+	}
+	// a chained pipeline and a command substitution
+	if got := score("a | b && echo $(c)"); got != 9 {
+		t.Errorf("complexity(a | b && echo $(c)) = %d, want 9", got)
+	}
+	if score("a | b | c") <= score("a | b && c | d") {
+		t.Error("a deeper pipeline should outscore a chain of shallow ones")
+	}
+}
+
+func TestPipes(t *testing.T) {
+	stats := analyzeLines(t,
+		"ls",
+		"cat log | grep error | sort | uniq -c",
+		"cat log | grep error | sort | uniq -c",
+		"ps aux | grep node",
+		"export TOKEN=one; env | grep TOKEN",
+		"export TOKEN=two; env | grep TOKEN",
+	)
+
+	if stats.PipeCount != 5 || int(stats.PipePct) != 83 {
+		t.Errorf("PipeCount = %d (%.1f%%), want 5", stats.PipeCount, stats.PipePct)
+	}
+	if stats.MaxPipeDepth != 4 || stats.LongestPipeline != "cat log | grep error | sort | uniq -c" {
+		t.Errorf("longest = %q (%d stages)", stats.LongestPipeline, stats.MaxPipeDepth)
+	}
+	if want := []int{3, 0, 2, 0}; !reflect.DeepEqual(stats.PipeDepths, want) {
+		t.Errorf("PipeDepths = %v, want %v", stats.PipeDepths, want)
+	}
+	if top := stats.PipeTargets[0]; top != (CommandCount{"grep", 5}) {
+		t.Errorf("top pipe target = %+v, want grep x5", top)
+	}
+
+	// repeats and lines that only differ in a secret are counted together
+	if len(stats.HallOfFame) != 3 {
+		t.Fatalf("HallOfFame = %+v, want 3 entries", stats.HallOfFame)
+	}
+	if top := stats.HallOfFame[0]; top.Command != "cat log | grep error | sort | uniq -c" || top.Count != 2 {
+		t.Errorf("top one-liner = %+v", top)
+	}
+	if env := stats.HallOfFame[1]; env.Command != "export TOKEN=***; env | grep TOKEN" || env.Count != 2 {
+		t.Errorf("redacted one-liner = %+v", env)
+	}
+}
+
+func TestPruneOneLiners(t *testing.T) {
+	// the longer the line, the higher its score
+	line := func(i int) string {
+		return fmt.Sprintf("a%d | b", i) + strings.Repeat(" ", i)
+	}
+	score := func(i int) int {
+		return complexity(line(i), parser.SplitPipelines(line(i)), 2)
+	}
+
+	p := newPipeTracker()
+	for i := range maxOneLiners + 1 {
+		e := newEvent(parser.ParseStage(line(i)), false)
+		p.Add(&e)
+	}
+
+	if len(p.oneLiners) != maxOneLiners/4 {
+		t.Fatalf("%d candidates left after pruning, want %d", len(p.oneLiners), maxOneLiners/4)
+	}
+	// only the best quarter survives
+	cutoff := score(maxOneLiners - maxOneLiners/4)
+	for _, one := range p.oneLiners {
+		if one.Score < cutoff {
+			t.Errorf("%q scoring %d survived, cutoff %d", strings.TrimSpace(one.Command), one.Score, cutoff)
+		}
+	}
+	if p.oneLiners[line(maxOneLiners)] == nil {
+		t.Error("the highest scoring one-liner was pruned")
+	}
+}
@@ -0,0 +1,240 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// riskRule flags commands whose expanded command line matches Pattern
+type RiskRule struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"` // "high", "medium" or "low"
+	Pattern     string `json:"pattern"`
+	// the pattern is only tried on lines containing one of these (case
+	// sensitive); without keywords it is tried on every line
+	Keywords []string `json:"keywords,omitempty"`
+
+	re *regexp.Regexp
+}
+
+// riskFinding summarizes every match of one rule
+type RiskFinding struct {
+	Rule        string    `json:"rule"`
+	Description string    `json:"description"`
+	Severity    string    `json:"severity"`
+	Count       int       `json:"count"`
+	First       time.Time `json:"first,omitzero"` // zero without timestamps
+	Last        time.Time `json:"last,omitzero"`
+	Example     string    `json:"example"` // first matching command
+}
+
+var defaultRiskRules = []RiskRule{
+	{
+		Name:        "rm-rf",
+		Description: "recursive forced delete",
+		Severity:    "high",
+		Pattern:     `\brm\s+(?:-\S+\s+)*(?:-[a-zA-Z]*(?:[rR][a-zA-Z]*f|f[a-zA-Z]*[rR])|-[rR]\s+-f|-f\s+-[rR]|--recursive\s+--force|--force\s+--recursive)\b`,
+		Keywords:    []string{"rm"},
+	},
+	{
+		Name:        "chmod-777",
+		Description: "recursive world-writable permissions",
+		Severity:    "high",
+		Pattern:     `\bchmod\s+(?:-\S+\s+)*(?:-R|--recursive)\s+(?:-\S+\s+)*0?777\b`,
+		Keywords:    []string{"chmod"},
+	},
+	{
+		Name:        "curl-pipe-sh",
+		Description: "remote script piped straight into a shell",
+		Severity:    "high",
+		Pattern:     `\b(?:curl|wget)\b[^|]*\|\s*(?:sudo\s+)?(?:ba|z|da|k)?sh\b`,
+		Keywords:    []string{"curl", "wget"},
+	},
+	{
+		Name:        "git-force-push",
+		Description: "force push that can overwrite remote history",
+		Severity:    "medium",
+		Pattern:     `\bgit\s+(?:\S+\s+)*push\b[^|;&]*\s(?:--force|-f)(?:\s|$)`,
+		Keywords:    []string{"push"},
+	},
+	{
+		Name:        "dd-device",
+		Description: "dd writing to a raw device",
+		Severity:    "high",
+		Pattern:     `\bdd\b[^|;&]*\bof=/dev/`,
+		Keywords:    []string{"of=/dev/"},
+	},
+	{
+		Name:        "kubectl-delete-prod",
+		Description: "kubectl delete against a production context or namespace",
+		Severity:    "high",
+		Pattern:     `\bkubectl\b[^|;&]*(?:\bdelete\b[^|;&]*prod|prod[^|;&]*\bdelete\b)`,
+		Keywords:    []string{"kubectl"},
+	},
+}
+
+// defaultRiskRules returns the built-in rules
+func DefaultRiskRules() []RiskRule {
+	rules := make([]RiskRule, len(defaultRiskRules))
+	copy(rules, defaultRiskRules)
+	for i := range rules {
+		rules[i].re = regexp.MustCompile(rules[i].Pattern)
+	}
+	return rules
+}
+
+// loadRiskRules reads extra rules from a JSON array of
+// {"name", "description", "severity", "pattern", "keywords"} objects and
+// merges them into base; a rule with an existing name replaces the built-in one
+func LoadRiskRules(path string, base []RiskRule) ([]RiskRule, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var extra []RiskRule
+	if err := json.Unmarshal(content, &extra); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	rules := append([]RiskRule(nil), base...)
+	for _, rule := range extra {
+		if rule.Name == "" || rule.Pattern == "" {
+			return nil, fmt.Errorf("%s: every rule needs a name and a pattern", path)
+		}
+		if rule.re, err = regexp.Compile(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("%s: rule %q: %w", path, rule.Name, err)
+		}
+		if rule.Severity == "" {
+			rule.Severity = "medium"
+		}
+
+		replaced := false
+		for i := range rules {
+			if rules[i].Name == rule.Name {
+				rules[i] = rule
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, nil
+}
+
+// riskTracker matches every command against the risk rules
+type riskTracker struct {
+	rules    []RiskRule
+	findings []RiskFinding // parallel to rules
+	risky    int
+}
+
+func newRiskTracker(rules []RiskRule) *riskTracker {
+	r := &riskTracker{rules: rules, findings: make([]RiskFinding, len(rules))}
+	for i, rule := range rules {
+		r.findings[i] = RiskFinding{Rule: rule.Name, Description: rule.Description, Severity: rule.Severity}
+	}
+	return r
+}
+
+func (r *riskTracker) Add(e *Event) {
+	cmd := e.Cmd
+	if len(r.rules) == 0 {
+		return
+	}
+
+	// match the expanded command so aliases can't hide anything
+	line := cmd.Raw
+	if cmd.Alias != "" {
+		line = strings.Join(append([]string{cmd.Command}, cmd.Args...), " ")
+	}
+
+	matched := false
+	for i := range r.rules {
+		if !r.rules[i].matches(line) {
+			continue
+		}
+		matched = true
+		f := &r.findings[i]
+		if f.Count == 0 {
+			f.Example = line
+		}
+		f.Count++
+		if cmd.HasTime {
+			if f.First.IsZero() || cmd.Timestamp.Before(f.First) {
+				f.First = cmd.Timestamp
+			}
+			if cmd.Timestamp.After(f.Last) {
+				f.Last = cmd.Timestamp
+			}
+		}
+	}
+	if matched {
+		r.risky++
+	}
+}
+
+// matches reports whether a command line trips the rule. the keywords are
+// a cheap filter, since most lines can't match and the regexp is the
+// expensive part
+func (rule *RiskRule) matches(line string) bool {
+	if rule.re == nil {
+		return false
+	}
+	if len(rule.Keywords) > 0 && !containsAnyOf(line, rule.Keywords) {
+		return false
+	}
+	return rule.re.MatchString(line)
+}
+
+func containsAnyOf(s string, substrings []string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *riskTracker) Finish(stats *Stats) {
+	for _, f := range r.findings {
+		if f.Count > 0 {
+			stats.RiskFindings = append(stats.RiskFindings, f)
+		}
+	}
+	sort.Slice(stats.RiskFindings, func(i, j int) bool {
+		a, b := stats.RiskFindings[i], stats.RiskFindings[j]
+		if severityRank(a.Severity) != severityRank(b.Severity) {
+			return severityRank(a.Severity) > severityRank(b.Severity)
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Rule < b.Rule
+	})
+
+	stats.RiskyCount = r.risky
+	if stats.TotalCommands > 0 {
+		stats.RiskyPct = float64(r.risky) / float64(stats.TotalCommands) * 100
+	}
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case "high":
+		return 3
+	case "medium":
+		return 2
+	case "low":
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,173 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// matchingRules returns the names of the default rules a line matches
+func matchingRules(line string) []string {
+	var names []string
+	for _, rule := range DefaultRiskRules() {
+		if rule.matches(line) {
+			names = append(names, rule.Name)
+		}
+	}
+	return names
+}
+
+func TestDefaultRiskRules(t *testing.T) {
+	tests := []struct {
+		line string
+		want string // rule name, "" for none
+	}{
+		{"rm -rf build", "rm-rf"},
+		{"rm -fr /tmp/x", "rm-rf"},
+		{"rm -r -f node_modules", "rm-rf"},
+		{"sudo rm -Rf /var/cache", "rm-rf"},
+		{"rm --recursive --force dist", "rm-rf"},
+		{"rm -r build", ""},
+		{"rm -f file.txt", ""},
+		{"chmod -R 777 /srv", "chmod-777"},
+		{"chmod 777 file", ""},
+		{"curl -fsSL https://get.example.com | sh", "curl-pipe-sh"},
+		{"wget -qO- https://x.sh | sudo bash", "curl-pipe-sh"},
+		{"curl https://api.example.com | jq .", ""},
+		{"git push --force origin main", "git-force-push"},
+		{"git push -f", "git-force-push"},
+		{"git push --force-with-lease", ""},
+		{"dd if=image.iso of=/dev/sdb bs=4M", "dd-device"},
+		{"dd if=/dev/zero of=test.img", ""},
+		{"kubectl delete pod api -n prod", "kubectl-delete-prod"},
+		{"kubectl --context prod-eu delete deploy web", "kubectl-delete-prod"},
+		{"kubectl delete pod api -n staging", ""},
+		{"kubectl get pods -n prod", ""},
+	}
+	for _, tt := range tests {
+		got := matchingRules(tt.line)
+		switch {
+		case tt.want == "" && len(got) > 0:
+			t.Errorf("%q matched %v, want no rule", tt.line, got)
+		case tt.want != "" && (len(got) != 1 || got[0] != tt.want):
+			t.Errorf("%q matched %v, want %s", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestRiskKeywords(t *testing.T) {
+	// every built-in pattern needs one of its keywords, so the filter
+	// never hides a match
+	for _, rule := range DefaultRiskRules() {
+		if len(rule.Keywords) == 0 {
+			t.Errorf("%s has no keywords", rule.Name)
+		}
+	}
+
+	rule := RiskRule{Pattern: `drop\s+table`, Keywords: []string{"psql"}}
+	rule.re = regexp.MustCompile(rule.Pattern)
+	if !rule.matches("psql -c 'drop table users'") {
+		t.Error("rule didn't match a line with its keyword")
+	}
+	if rule.matches("mysql -e 'drop table users'") {
+		t.Error("rule matched a line without any of its keywords")
+	}
+	rule.Keywords = nil
+	if !rule.matches("mysql -e 'drop table users'") {
+		t.Error("rule without keywords didn't match")
+	}
+}
+
+func TestRiskFindings(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(at(3, 9, 0), "git push -f"),
+		timed(at(4, 9, 0), "rm -rf build"),
+		timed(at(5, 9, 0), "ls"),
+		timed(at(6, 9, 0), "rm -rf dist"),
+		timed(at(7, 9, 0), "git push --force"),
+		timed(at(8, 9, 0), "git push --force"),
+	)
+
+	if stats.RiskyCount != 5 {
+		t.Errorf("RiskyCount = %d, want 5", stats.RiskyCount)
+	}
+	if len(stats.RiskFindings) != 2 {
+		t.Fatalf("RiskFindings = %+v, want rm-rf and git-force-push", stats.RiskFindings)
+	}
+	// high severity first, even though force pushes are more common
+	rm, push := stats.RiskFindings[0], stats.RiskFindings[1]
+	if rm.Rule != "rm-rf" || push.Rule != "git-force-push" {
+		t.Fatalf("findings in order %s, %s; want rm-rf, git-force-push", rm.Rule, push.Rule)
+	}
+	if rm.Count != 2 || rm.Example != "rm -rf build" {
+		t.Errorf("rm-rf = %+v, want 2 matches, first one as example", rm)
+	}
+	if !rm.First.Equal(at(4, 9, 0)) || !rm.Last.Equal(at(6, 9, 0)) {
+		t.Errorf("rm-rf seen %v to %v, want %v to %v", rm.First, rm.Last, at(4, 9, 0), at(6, 9, 0))
+	}
+	if push.Count != 3 {
+		t.Errorf("git-force-push count = %d, want 3", push.Count)
+	}
+}
+
+func TestLoadRiskRules(t *testing.T) {
+	dir := t.TempDir()
+	write := func(content string) string {
+		path := filepath.Join(dir, "rules.json")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	rules, err := LoadRiskRules(write(`[
+		{"name": "terraform-destroy", "pattern": "terraform\\s+destroy", "keywords": ["destroy"]},
+		{"name": "rm-rf", "severity": "low", "pattern": "rm\\s+-rf\\s+/"}
+	]`), DefaultRiskRules())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != len(defaultRiskRules)+1 {
+		t.Fatalf("got %d rules, want the defaults plus one", len(rules))
+	}
+	byName := make(map[string]RiskRule)
+	for _, rule := range rules {
+		byName[rule.Name] = rule
+	}
+	if rule := byName["terraform-destroy"]; rule.Severity != "medium" || !rule.matches("terraform destroy") || len(rule.Keywords) != 1 {
+		t.Errorf("terraform-destroy = %+v, want a compiled medium rule with its keyword", rule)
+	}
+	// a rule with a built-in name replaces it
+	if rule := byName["rm-rf"]; rule.Severity != "low" || rule.re.MatchString("rm -rf build") {
+		t.Errorf("rm-rf was not replaced: %+v", rule)
+	}
+
+	for _, bad := range []string{
+		`{"name": "x"}`,
+		`[{"name": "x"}]`,
+		`[{"name": "x", "pattern": "("}]`,
+	} {
+		if _, err := LoadRiskRules(write(bad), nil); err == nil {
+			t.Errorf("LoadRiskRules accepted %s", bad)
+		}
+	}
+	if _, err := LoadRiskRules(filepath.Join(dir, "missing.json"), nil); err == nil {
+		t.Error("LoadRiskRules accepted a missing file")
+	}
+}
+
+func TestRiskRulesSeeExpandedAliases(t *testing.T) {
+	r := newRiskTracker(DefaultRiskRules())
+	// alias nuke='rm -rf'
+	cmd := &parser.Command{Raw: "nuke build", Command: "rm", Args: []string{"-rf", "build"}, Alias: "nuke"}
+	r.Add(&Event{Cmd: cmd, Base: "rm"})
+
+	stats := &Stats{}
+	r.Finish(stats)
+	if len(stats.RiskFindings) != 1 || stats.RiskFindings[0].Example != "rm -rf build" {
+		t.Errorf("RiskFindings = %+v, want rm-rf on the expanded command", stats.RiskFindings)
+	}
+}
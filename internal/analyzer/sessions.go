@@ -0,0 +1,122 @@
+package analyzer
+
+import (
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// idle time that ends a work session
+const DefaultSessionGap = 30 * time.Minute
+
+// options tunes the analysis
+type Options struct {
+	SessionGap time.Duration // idle gap that splits two work sessions
+	RiskRules  []RiskRule    // rules for the dangerous command audit
+	HashHosts  bool          // replace remote hostnames with digests in every output
+	SkipLedger bool          // don't keep every package event, only the tallies
+	// nameInUse reports names the shell already resolves (commands on PATH,
+	// aliases, functions) so suggested aliases don't shadow them. nil when
+	// only the history is known
+	NameInUse func(name string) bool
+}
+
+// defaultOptions returns the options used by Analyze
+func DefaultOptions() Options {
+	return Options{
+		SessionGap: DefaultSessionGap,
+		RiskRules:  DefaultRiskRules(),
+	}
+}
+
+// sessionTracker splits timestamped commands into work sessions on idle gaps
+type sessionTracker struct {
+	gap time.Duration
+
+	// current session
+	start, end time.Time
+	commands   int
+
+	// aggregates over finished sessions
+	count         int
+	totalLength   time.Duration
+	totalCommands int
+	longest       time.Duration
+	longestStart  time.Time
+	startHours    [24]int
+	endHours      [24]int
+	days          map[string]bool
+}
+
+func newSessionTracker(gap time.Duration) *sessionTracker {
+	if gap <= 0 {
+		gap = DefaultSessionGap
+	}
+	return &sessionTracker{gap: gap, days: make(map[string]bool)}
+}
+
+// add records a command and reports whether it starts a new session
+func (s *sessionTracker) add(cmd *parser.Command) bool {
+	if !cmd.HasTime {
+		return false
+	}
+
+	if s.commands > 0 && cmd.Timestamp.Sub(s.end) <= s.gap {
+		if cmd.Timestamp.After(s.end) {
+			s.end = cmd.Timestamp
+		}
+		s.commands++
+		return false
+	}
+
+	newSession := s.commands > 0
+	s.close()
+	s.start, s.end, s.commands = cmd.Timestamp, cmd.Timestamp, 1
+	return newSession
+}
+
+// close folds the current session into the aggregates
+func (s *sessionTracker) close() {
+	if s.commands == 0 {
+		return
+	}
+	length := s.end.Sub(s.start)
+	s.count++
+	s.totalLength += length
+	s.totalCommands += s.commands
+	if length > s.longest || s.longestStart.IsZero() {
+		s.longest = length
+		s.longestStart = s.start
+	}
+	s.startHours[s.start.Hour()]++
+	s.endHours[s.end.Hour()]++
+	s.days[s.start.Format("2006-01-02")] = true
+	s.commands = 0
+}
+
+func (s *sessionTracker) finish(stats *Stats) {
+	s.close()
+	if s.count == 0 {
+		return
+	}
+
+	stats.SessionCount = s.count
+	stats.SessionsPerDay = float64(s.count) / float64(len(s.days))
+	stats.AvgSessionLength = s.totalLength / time.Duration(s.count)
+	stats.LongestSession = s.longest
+	stats.LongestSessionStart = s.longestStart
+	stats.CommandsPerSession = float64(s.totalCommands) / float64(s.count)
+	stats.TypicalSessionStart = peakHour(s.startHours)
+	stats.TypicalSessionEnd = peakHour(s.endHours)
+}
+
+// peakHour returns the busiest hour of a histogram (earliest wins ties)
+func peakHour(hours [24]int) int {
+	peak := 0
+	for h := 1; h < 24; h++ {
+		if hours[h] > hours[peak] {
+			peak = h
+		}
+	}
+	return peak
+}
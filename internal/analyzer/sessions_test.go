@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessions(t *testing.T) {
+	stats := analyzeLines(t,
+		// 09:00-09:50, gaps of 25 minutes stay in one session
+		timed(at(3, 9, 0), "ls"),
+		timed(at(3, 9, 25), "ls"),
+		timed(at(3, 9, 50), "ls"),
+		// a 70 minute gap starts a new session
+		timed(at(3, 11, 0), "ls"),
+		timed(at(3, 11, 10), "ls"),
+		// and so does the next day
+		timed(at(4, 9, 0), "ls"),
+	)
+
+	if stats.SessionCount != 3 {
+		t.Fatalf("SessionCount = %d, want 3", stats.SessionCount)
+	}
+	if stats.SessionsPerDay != 1.5 {
+		t.Errorf("SessionsPerDay = %v, want 1.5", stats.SessionsPerDay)
+	}
+	if stats.LongestSession != 50*time.Minute {
+		t.Errorf("LongestSession = %v, want 50m", stats.LongestSession)
+	}
+	if !stats.LongestSessionStart.Equal(at(3, 9, 0)) {
+		t.Errorf("LongestSessionStart = %v, want %v", stats.LongestSessionStart, at(3, 9, 0))
+	}
+	if want := 20 * time.Minute; stats.AvgSessionLength != want {
+		t.Errorf("AvgSessionLength = %v, want %v", stats.AvgSessionLength, want)
+	}
+	if stats.CommandsPerSession != 2 {
+		t.Errorf("CommandsPerSession = %v, want 2", stats.CommandsPerSession)
+	}
+	if stats.TypicalSessionStart != 9 {
+		t.Errorf("TypicalSessionStart = %d, want 9", stats.TypicalSessionStart)
+	}
+}
+
+func TestSessionGapOption(t *testing.T) {
+	lines := []string{
+		timed(at(3, 9, 0), "ls"),
+		timed(at(3, 9, 20), "ls"),
+		timed(at(3, 9, 40), "ls"),
+	}
+	opts := DefaultOptions()
+	opts.SessionGap = 10 * time.Minute
+	if got := analyzeLinesWith(t, opts, lines...).SessionCount; got != 3 {
+		t.Errorf("with a 10m gap SessionCount = %d, want 3", got)
+	}
+	if got := analyzeLines(t, lines...).SessionCount; got != 1 {
+		t.Errorf("with the default gap SessionCount = %d, want 1", got)
+	}
+}
+
+func TestSessionsNeedTimestamps(t *testing.T) {
+	stats := analyzeLines(t, "ls", "cd", "ls")
+	if stats.SessionCount != 0 {
+		t.Errorf("SessionCount = %d without timestamps, want 0", stats.SessionCount)
+	}
+}
+
+func TestPeakHour(t *testing.T) {
+	var hours [24]int
+	if got := peakHour(hours); got != 0 {
+		t.Errorf("peakHour of an empty histogram = %d, want 0", got)
+	}
+	hours[14], hours[9] = 3, 3
+	if got := peakHour(hours); got != 9 {
+		t.Errorf("peakHour = %d, want the earliest of the tied hours (9)", got)
+	}
+}
@@ -0,0 +1,239 @@
+package analyzer
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// sshStats covers remote sessions and file transfers
+type SSHStats struct {
+	Sessions       int // ssh and mosh logins
+	Transfers      int // scp, rsync and sftp runs
+	Uploads        int
+	Downloads      int
+	TopHosts       []CommandCount
+	DistinctHosts  int
+	RemoteHours    [24]int // when remote sessions start (needs timestamps)
+	PeakRemoteHour int
+}
+
+const topHostCount = 5
+
+// ssh/mosh options that take a value
+var sshValueFlags = map[string]bool{
+	"-p": true, "-i": true, "-l": true, "-o": true, "-F": true, "-J": true, "-L": true,
+	"-R": true, "-D": true, "-b": true, "-c": true, "-E": true, "-e": true, "-m": true,
+	"-O": true, "-Q": true, "-S": true, "-W": true, "-w": true, "-B": true, "-P": true,
+	"--port": true, "--ssh": true, "--predict": true,
+}
+
+// sshTracker collects remote hosts and transfer directions. with HashHosts
+// the events it gets already name hosts by their digest
+type sshTracker struct {
+	ssh   SSHStats
+	hosts *topCounts[string]
+}
+
+func newSSHTracker() *sshTracker {
+	return &sshTracker{hosts: newTopCounts[string](maxTrackedKeys)}
+}
+
+func (s *sshTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	args := commandArgs(cmd, baseCmd)
+
+	switch baseCmd {
+	case "ssh", "mosh":
+		target := subcommandOf(args, sshValueFlags)
+		if target == "" {
+			return
+		}
+		s.ssh.Sessions++
+		s.addHost(hostOf(target))
+		if cmd.HasTime {
+			s.ssh.RemoteHours[cmd.Timestamp.Hour()]++
+		}
+	case "sftp":
+		if target := subcommandOf(args, sshValueFlags); target != "" {
+			s.ssh.Transfers++
+			s.addHost(hostOf(target))
+		}
+	case "scp", "rsync":
+		paths := positionalArgs(args, sshValueFlags)
+		if len(paths) < 2 {
+			return
+		}
+		s.ssh.Transfers++
+		dest := paths[len(paths)-1]
+		remoteSource := false
+		for _, src := range paths[:len(paths)-1] {
+			if host := remoteHost(src); host != "" {
+				remoteSource = true
+				s.addHost(host)
+			}
+		}
+		destHost := remoteHost(dest)
+		if destHost != "" {
+			s.addHost(destHost)
+		}
+		switch {
+		case destHost != "" && !remoteSource:
+			s.ssh.Uploads++
+		case destHost == "" && remoteSource:
+			s.ssh.Downloads++
+		}
+	}
+}
+
+func (s *sshTracker) addHost(host string) {
+	if host != "" {
+		s.hosts.add(host)
+	}
+}
+
+func (s *sshTracker) Finish(stats *Stats) {
+	s.ssh.TopHosts = topN(s.hosts.snapshot(stats), topHostCount)
+	// a lower bound once hosts had to be evicted
+	s.ssh.DistinctHosts = s.hosts.distinct()
+	s.ssh.PeakRemoteHour = peakHour(s.ssh.RemoteHours)
+	stats.SSH = s.ssh
+}
+
+// positionalArgs returns arguments that are not options or option values
+func positionalArgs(args []string, valueFlags map[string]bool) []string {
+	var positional []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if isShellOperator(arg) {
+			break
+		}
+		if valueFlags[arg] {
+			i++
+			continue
+		}
+		if !strings.HasPrefix(arg, "-") {
+			positional = append(positional, unquote(arg))
+		}
+	}
+	return positional
+}
+
+// remoteHost returns the host of an scp/rsync path like user@host:dir or
+// host::module, or "" for a local path
+func remoteHost(path string) string {
+	host, _, found := strings.Cut(path, ":")
+	// a slash before the colon means a local path (./a:b)
+	if !found || host == "" || strings.Contains(host, "/") {
+		return ""
+	}
+	return hostOf(host)
+}
+
+// hostOf strips the user (and an ssh:// scheme) from a target
+func hostOf(target string) string {
+	return strings.ToLower(typedHost(target))
+}
+
+// typedHost is hostOf keeping the host as it was typed
+func typedHost(target string) string {
+	target = strings.TrimPrefix(target, "ssh://")
+	if i := strings.LastIndex(target, "@"); i >= 0 {
+		target = target[i+1:]
+	}
+	target, _, _ = strings.Cut(target, "/")
+	if host, port, found := strings.Cut(target, ":"); found && port != "" {
+		target = host
+	}
+	return target
+}
+
+// remoteHosts returns the hosts a remote command connects to, as typed
+func remoteHosts(baseCmd string, args []string) []string {
+	switch baseCmd {
+	case "ssh", "mosh", "sftp":
+		if target := subcommandOf(args, sshValueFlags); target != "" {
+			return []string{typedHost(unquote(target))}
+		}
+	case "scp", "rsync":
+		var hosts []string
+		for _, path := range positionalArgs(args, sshValueFlags) {
+			if host, _, found := strings.Cut(path, ":"); found && host != "" && !strings.Contains(host, "/") {
+				hosts = append(hosts, typedHost(host))
+			}
+		}
+		return hosts
+	}
+	return nil
+}
+
+// hideHosts returns cmd with every host it connects to, in any stage of
+// the line, replaced by its digest, or cmd itself when there are none
+func hideHosts(cmd *parser.Command, baseCmd string, pipelines [][]string) *parser.Command {
+	var hosts []string
+	if len(pipelines) == 1 && len(pipelines[0]) == 1 {
+		hosts = remoteHosts(baseCmd, commandArgs(cmd, baseCmd))
+	} else {
+		for _, stages := range pipelines {
+			for _, stage := range stages {
+				if c := parser.ParseStage(stage); c != nil {
+					base := parser.GetBaseCommand(c)
+					hosts = append(hosts, remoteHosts(base, commandArgs(c, base))...)
+				}
+			}
+		}
+	}
+	if len(hosts) == 0 {
+		return cmd
+	}
+
+	hidden := *cmd
+	hidden.Raw = replaceHosts(cmd.Raw, hosts)
+	hidden.Args = make([]string, len(cmd.Args))
+	for i, arg := range cmd.Args {
+		hidden.Args[i] = replaceHosts(arg, hosts)
+	}
+	return &hidden
+}
+
+// replaceHosts replaces whole-name occurrences of the hosts in s by their
+// digests, so prod becomes a digest but production stays
+func replaceHosts(s string, hosts []string) string {
+	isHostByte := func(b byte) bool {
+		return b == '.' || b == '-' || b == '_' || b >= '0' && b <= '9' ||
+			b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z'
+	}
+	for _, host := range hosts {
+		if host == "" {
+			continue
+		}
+		digest := hashHost(strings.ToLower(host))
+		var sb strings.Builder
+		rest := s
+		for {
+			i := strings.Index(rest, host)
+			if i < 0 {
+				break
+			}
+			end := i + len(host)
+			if (i > 0 && isHostByte(rest[i-1])) || (end < len(rest) && isHostByte(rest[end])) {
+				sb.WriteString(rest[:end])
+			} else {
+				sb.WriteString(rest[:i])
+				sb.WriteString(digest)
+			}
+			rest = rest[end:]
+		}
+		sb.WriteString(rest)
+		s = sb.String()
+	}
+	return s
+}
+
+// hashHost replaces a hostname with a short stable digest for sharing
+func hashHost(host string) string {
+	sum := sha256.Sum256([]byte(host))
+	return "host-" + hex.EncodeToString(sum[:])[:8]
+}
@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHostOf(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"build.example.com", "build.example.com"},
+		{"deploy@Web-1", "web-1"},
+		{"ssh://git@github.com:22/repo", "github.com"},
+		{"user@host:2222", "host"},
+	}
+	for _, tt := range tests {
+		if got := hostOf(tt.target); got != tt.want {
+			t.Errorf("hostOf(%q) = %q, want %q", tt.target, got, tt.want)
+		}
+	}
+}
+
+func TestRemoteHost(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"user@box:~/logs", "box"},
+		{"backup::module/dir", "backup"},
+		{"./a:b", ""},
+		{"local/file.txt", ""},
+		{":relative", ""},
+	}
+	for _, tt := range tests {
+		if got := remoteHost(tt.path); got != tt.want {
+			t.Errorf("remoteHost(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestPositionalArgs(t *testing.T) {
+	got := positionalArgs([]string{"-P", "2222", "-r", `"my dir"`, "box:/srv", "|", "tee", "log"}, sshValueFlags)
+	if want := []string{"my dir", "box:/srv"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("positionalArgs = %q, want %q", got, want)
+	}
+}
+
+func TestSSH(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(at(3, 9, 0), "ssh -p 2222 deploy@web-1"),
+		timed(at(3, 21, 0), "ssh web-1"),
+		timed(at(4, 21, 30), "mosh db"),
+		timed(at(4, 22, 0), "ssh -v"),
+		timed(at(5, 9, 0), "scp build.tgz web-1:/srv"),
+		timed(at(5, 9, 5), "rsync -avz db:/var/backups ."),
+		timed(at(5, 9, 10), "scp web-1:a db:b"),
+		timed(at(5, 9, 15), "sftp files"),
+	)
+	ssh := stats.SSH
+
+	if ssh.Sessions != 3 {
+		t.Errorf("Sessions = %d, want 3", ssh.Sessions)
+	}
+	if ssh.Transfers != 4 || ssh.Uploads != 1 || ssh.Downloads != 1 {
+		t.Errorf("transfers = %d (%d up, %d down), want 4 (1 up, 1 down)", ssh.Transfers, ssh.Uploads, ssh.Downloads)
+	}
+	if want := []CommandCount{{"web-1", 4}, {"db", 3}, {"files", 1}}; !reflect.DeepEqual(ssh.TopHosts, want) {
+		t.Errorf("TopHosts = %+v, want %+v", ssh.TopHosts, want)
+	}
+	if ssh.DistinctHosts != 3 {
+		t.Errorf("DistinctHosts = %d, want 3", ssh.DistinctHosts)
+	}
+	if ssh.RemoteHours[21] != 2 || ssh.PeakRemoteHour != 21 {
+		t.Errorf("21:00 logins = %d, peak %02d:00; want 2 at 21:00", ssh.RemoteHours[21], ssh.PeakRemoteHour)
+	}
+}
+
+func TestHashHosts(t *testing.T) {
+	opts := DefaultOptions()
+	opts.HashHosts = true
+	stats := analyzeLinesWith(t, opts, "ssh prod.internal", "ssh PROD.internal")
+
+	host := stats.SSH.TopHosts[0]
+	if host.Command != hashHost("prod.internal") || host.Count != 2 {
+		t.Errorf("top host = %+v, want a hash of prod.internal x2", host)
+	}
+	if strings.Contains(host.Command, "prod") || hashHost("a") == hashHost("b") {
+		t.Errorf("hashHost leaks or collides: %q", host.Command)
+	}
+}
+
+func TestHashHostsEverywhere(t *testing.T) {
+	opts := DefaultOptions()
+	opts.HashHosts = true
+	var lines []string
+	for range 6 {
+		lines = append(lines,
+			"ssh deploy@build.example.com",
+			"ssh prod 'journalctl -u api' | grep -i error | sort | uniq -c | sort -rn",
+			"rsync -av dist/ prod:/srv && ssh -p 2222 prod systemctl restart api",
+			"scp backup.tar.gz deploy@build.example.com:/srv",
+			"ssh prod sudo rm -rf /var/cache/api",
+		)
+	}
+	stats := analyzeLinesWith(t, opts, lines...)
+
+	// everything a command line can surface in
+	var shown []string
+	for _, s := range stats.AliasSuggestions {
+		shown = append(shown, s.Command)
+	}
+	for _, one := range stats.HallOfFame {
+		shown = append(shown, one.Command)
+	}
+	for _, w := range stats.TopWorkflows {
+		shown = append(shown, w.Steps...)
+	}
+	for _, finding := range stats.RiskFindings {
+		shown = append(shown, finding.Example)
+	}
+	for _, typo := range stats.TypoAliases {
+		shown = append(shown, typo.Typo, typo.Intended)
+	}
+	shown = append(shown, stats.LongestPipeline)
+	if len(stats.HallOfFame) == 0 || len(stats.AliasSuggestions) == 0 {
+		t.Fatalf("nothing to check: %d one-liners, %d aliases", len(stats.HallOfFame), len(stats.AliasSuggestions))
+	}
+	for _, text := range shown {
+		if strings.Contains(text, "example.com") || strings.Contains(text, "prod") {
+			t.Errorf("hostname shown with -hash-hosts: %q", text)
+		}
+	}
+	// the same digest everywhere, and names that merely contain a host stay
+	if !strings.Contains(stats.LongestPipeline, hashHost("prod")) {
+		t.Errorf("LongestPipeline = %q, want %s", stats.LongestPipeline, hashHost("prod"))
+	}
+	if got := replaceHosts("prod production prod:/srv", []string{"prod"}); got != hashHost("prod")+" production "+hashHost("prod")+":/srv" {
+		t.Errorf("replaceHosts = %q", got)
+	}
+}
@@ -13,6 +13,9 @@ type Stats struct {
 	// basic counts
 	TotalCommands  int
 	UniqueCommands int
+	// the history had too many distinct lines, files or hosts to count
+	// every one, so long-tail counts are estimates
+	Approximate bool
 
 	// time-based stats (only if timestamps available)
 	HasTimeData    bool
@@ -24,6 +27,16 @@ type Stats struct {
 	BusiestDay     time.Time
 	BusiestDayCount int
 
+	// streaks (civil dates in the history's zone)
+	CurrentStreak      int // 0 unless the latest streak reaches today or yesterday
+	LongestStreakStart time.Time
+	LongestStreakEnd   time.Time
+	LongestBreak       int // most days in a row without a command
+	LongestBreakStart  time.Time
+	LongestBreakEnd    time.Time
+	ActiveDays         int
+	Streaks            []Streak // every run of active days, oldest first
+
 	// hour/day heatmap [day][hour] = count (0=Sunday, 1=Monday, ...)
 	HeatMap [7][24]int
 
@@ -43,7 +56,7 @@ type Stats struct {
 	// command analysis
 	SudoCount       int
 	SudoPct         float64
-	PipeCount       int
+	PipeCount       int // commands with a real pipe (quoted | doesn't count)
 	PipePct         float64
 	AvgCommandLen   float64
 	LongestCommand  string
@@ -52,10 +65,100 @@ type Stats struct {
 	// fun facts
 	MostRepeated      string
 	MostRepeatedCount int
-	FavoriteDir       string
+	FavoriteDir       string // most visited cd target, resolved against the working directory
 	FavoriteDirCount  int
 	EditorChoice      string
 	EditorCount       int
+
+	// files opened in editors
+	EditorSplit         []CommandCount // every editor used, most used first
+	EditedFiles         int
+	TopEditedFiles      []CommandCount
+	TopEditedExtensions []CommandCount
+	TopConfigFiles      []CommandCount // dotfiles, Dockerfile, Makefile, ...
+
+	// work sessions (split on idle gaps, only with timestamps)
+	SessionCount        int
+	SessionsPerDay      float64
+	AvgSessionLength    time.Duration
+	LongestSession      time.Duration
+	LongestSessionStart time.Time
+	CommandsPerSession  float64
+	TypicalSessionStart int // hour of day
+	TypicalSessionEnd   int // hour of day
+
+	// typos (mistyped commands and what was meant)
+	TopTypos    []Typo
+	TypoAliases []Typo // typos frequent enough to deserve an alias
+	TypoCount   int
+	TypoPct     float64
+
+	// aliases resolved from shell rc files
+	AliasUsage   []CommandCount // alias name -> times typed
+	AliasedCount int
+	AliasedPct   float64
+
+	// alias suggestions for long, repeated commands
+	AliasSuggestions []AliasSuggestion
+
+	// git deep dive
+	Git GitStats
+
+	// kubernetes, terraform and cloud CLIs
+	Infra InfraStats
+
+	// ssh sessions and file transfers
+	SSH SSHStats
+
+	// packages installed and removed
+	Packages PackageStats
+
+	// pipelines
+	AvgPipeDepth    float64 // stages per piped command
+	MaxPipeDepth    int
+	LongestPipeline string         // redacted
+	PipeDepths      []int          // piped commands with 2, 3, 4 and 5+ stages
+	PipeTargets     []CommandCount // commands most often piped into
+	HallOfFame      []OneLiner     // most complex one-liners, redacted
+
+	// programming languages inferred from toolchains and file extensions
+	Languages     []LanguageCount
+	LanguageCount int     // languages with a few commands or more
+	PolyglotScore float64 // effective number of languages (1 = only one)
+
+	// flags and options
+	TopFlags         []ToolFlags     // most used flags of the tools that get the most flags
+	FlagHeavy        []FlagHeaviness // tools with the most flags per invocation
+	FlagCount        int
+	DistinctFlags    int
+	FlagsPerCommand  float64
+	MostFlagsCommand string
+	MostFlagsCount   int
+
+	// trends (needs timestamps), one entry per month/week from first to last command
+	MonthlyTrend []MonthTrend
+	WeeklyCounts []WeekCount
+
+	// tool adoption (needs timestamps)
+	NewTools         []ToolAdoption  // first used after the history's first month, newest first
+	AbandonedTools   []ToolAdoption  // not used in the last two months, most recently dropped first
+	Discoveries      []ToolAdoption  // adopted in the last year and still in use, most used first
+	AdoptionTimeline []AdoptionMonth // tools first used each month, oldest month first
+
+	// dangerous commands matched by the risk rules
+	RiskFindings []RiskFinding
+	RiskyCount   int
+	RiskyPct     float64
+
+	// projects (repos and folders under ~/code and friends)
+	TopProjects      []Project
+	ProjectCount     int
+	ProjectsPerMonth []MonthCount // distinct projects touched each month
+
+	// workflows (consecutive command sequences)
+	TopWorkflows   []Workflow
+	SignatureCombo Workflow
+	Transitions    []Transition
 }
 
 // commandCount holds a command and its count
@@ -74,160 +177,132 @@ var categoryCommands = map[string][]string{
 	"Search":     {"grep", "rg", "ag", "find", "fd", "fzf", "ack", "locate"},
 	"Network":    {"ssh", "scp", "curl", "wget", "rsync", "ping", "nc", "netcat", "telnet", "sftp"},
 	"Files":      {"cat", "less", "head", "tail", "rm", "cp", "mv", "mkdir", "touch", "chmod", "chown", "ln", "bat"},
+	"Cloud":      {"aws", "gcloud", "gsutil", "az", "terraform", "tofu", "terragrunt", "pulumi", "eksctl", "doctl", "flyctl", "heroku", "ansible", "ansible-playbook"},
 }
 
-// analyze computes all statistics from history data
+// analyze computes all statistics from history data with the default options
 func Analyze(data *parser.HistoryData) *Stats {
-	stats := &Stats{
-		TotalCommands: len(data.Commands),
-		HasTimeData:   data.HasTimes,
-		Categories:    make(map[string]int),
-		CategoryPct:   make(map[string]float64),
-	}
+	return AnalyzeWithOptions(data, DefaultOptions())
+}
+
+// analyzeWithOptions computes all statistics from history data
+func AnalyzeWithOptions(data *parser.HistoryData, opts Options) *Stats {
+	stats, _ := AnalyzeStream(data.Stream(), data.ParsedAt, opts)
+	return stats
+}
+
+// commandTracker collects the basic counts: commands, repeats, sudo,
+// categories and the activity heatmap
+type commandTracker struct {
+	stats       *Stats
+	counts      *topCounts[string] // base command -> uses, shared with other trackers
+	aliasCounts map[string]int     // aliases the user typed
+	totalLen    int                // total command length for the average
+
+	// consecutive repeats
+	last          string
+	currentRepeat int
+}
 
-	if stats.TotalCommands == 0 {
-		return stats
+func newCommandTracker(stats *Stats, counts *topCounts[string]) *commandTracker {
+	return &commandTracker{stats: stats, counts: counts, aliasCounts: make(map[string]int)}
+}
+
+func (c *commandTracker) Add(e *Event) {
+	cmd, stats := e.Cmd, c.stats
+	c.counts.add(e.Base)
+
+	// track raw command length (use parsed command, not raw with timestamp)
+	c.totalLen += len(cmd.Raw)
+	if len(cmd.Raw) > stats.LongestCmdLen {
+		stats.LongestCmdLen = len(cmd.Raw)
+		stats.LongestCommand = cmd.Raw
 	}
 
-	// count commands
-	commandCounts := make(map[string]int)
 	// track consecutive repeats
-	consecutiveRepeats := make(map[string]int)
-	lastCmd := ""
-	currentRepeat := 0
+	if cmd.Raw == c.last {
+		c.currentRepeat++
+	} else {
+		c.endRepeat(stats)
+		c.currentRepeat = 1
+	}
+	c.last = cmd.Raw
 
-	// track directories
-	dirCounts := make(map[string]int)
-	
-	// track editors
-	editorCounts := make(map[string]int)
-	editors := map[string]bool{"vim": true, "nvim": true, "nano": true, "emacs": true, "code": true, "cursor": true, "subl": true, "micro": true, "hx": true, "helix": true}
-
-	// track days for streak calculation
-	activeDays := make(map[string]bool)
-
-	// total command length for average
-	totalCmdLen := 0
-
-	for _, cmd := range data.Commands {
-		baseCmd := parser.GetBaseCommand(&cmd)
-		commandCounts[baseCmd]++
-
-		// track raw command length (use parsed command, not raw with timestamp)
-		cmdStr := cmd.Raw
-		totalCmdLen += len(cmdStr)
-		if len(cmdStr) > stats.LongestCmdLen {
-			stats.LongestCmdLen = len(cmdStr)
-			stats.LongestCommand = cmdStr
-		}
+	// track aliases
+	if cmd.Alias != "" {
+		c.aliasCounts[cmd.Alias]++
+		stats.AliasedCount++
+	}
 
-		// track consecutive repeats
-		if cmd.Raw == lastCmd {
-			currentRepeat++
-		} else {
-			if currentRepeat > stats.MostRepeatedCount {
-				stats.MostRepeatedCount = currentRepeat
-				stats.MostRepeated = lastCmd
-			}
-			currentRepeat = 1
-		}
-		lastCmd = cmd.Raw
-		consecutiveRepeats[cmd.Raw] = max(consecutiveRepeats[cmd.Raw], currentRepeat)
+	// track sudo
+	if cmd.Command == "sudo" || cmd.Command == "doas" {
+		stats.SudoCount++
+	}
 
-		// track sudo
-		if cmd.Command == "sudo" || cmd.Command == "doas" {
-			stats.SudoCount++
-		}
+	// categorize
+	if category := CategoryOf(e.Base); category != "" {
+		stats.Categories[category]++
+	}
 
-		// track pipes
-		if strings.Contains(cmd.Raw, "|") {
-			stats.PipeCount++
+	// time-based analysis
+	if cmd.HasTime {
+		stats.HasTimeData = true
+		if stats.FirstCommand.IsZero() || cmd.Timestamp.Before(stats.FirstCommand) {
+			stats.FirstCommand = cmd.Timestamp
 		}
-
-		// track directories (cd commands)
-		if baseCmd == "cd" && len(cmd.Args) > 0 {
-			dir := cmd.Args[0]
-			// normalize some common patterns
-			if strings.HasPrefix(dir, "~/") || dir == "~" {
-				dir = "~" + dir[1:]
-			}
-			dirCounts[dir]++
+		if cmd.Timestamp.After(stats.LastCommand) {
+			stats.LastCommand = cmd.Timestamp
 		}
 
-		// track editors
-		if editors[baseCmd] {
-			editorCounts[baseCmd]++
-		}
+		// heatmap
+		hour := cmd.Timestamp.Hour()
+		day := int(cmd.Timestamp.Weekday())
+		stats.HeatMap[day][hour]++
 
-		// categorize
-		for category, commands := range categoryCommands {
-			for _, c := range commands {
-				if baseCmd == c {
-					stats.Categories[category]++
-					break
-				}
-			}
+		// night owl (midnight to 5am)
+		if hour >= 0 && hour < 5 {
+			stats.NightOwlPct++
 		}
 
-		// time-based analysis
-		if cmd.HasTime {
-			if stats.FirstCommand.IsZero() || cmd.Timestamp.Before(stats.FirstCommand) {
-				stats.FirstCommand = cmd.Timestamp
-			}
-			if cmd.Timestamp.After(stats.LastCommand) {
-				stats.LastCommand = cmd.Timestamp
-			}
-
-			// track active days for streak
-			dayKey := cmd.Timestamp.Format("2006-01-02")
-			activeDays[dayKey] = true
-
-			// heatmap
-			hour := cmd.Timestamp.Hour()
-			day := int(cmd.Timestamp.Weekday())
-			stats.HeatMap[day][hour]++
-
-			// night owl (midnight to 5am)
-			if hour >= 0 && hour < 5 {
-				stats.NightOwlPct++
-			}
-
-			// weekend
-			if day == 0 || day == 6 {
-				stats.WeekendPct++
-			}
+		// weekend
+		if day == 0 || day == 6 {
+			stats.WeekendPct++
 		}
 	}
+}
+
+// endRepeat records the run of repeats that just ended
+func (c *commandTracker) endRepeat(stats *Stats) {
+	if c.currentRepeat > stats.MostRepeatedCount {
+		stats.MostRepeatedCount = c.currentRepeat
+		stats.MostRepeated = c.last
+	}
+}
 
+func (c *commandTracker) Finish(stats *Stats) {
 	// check last repeat
-	if currentRepeat > stats.MostRepeatedCount {
-		stats.MostRepeatedCount = currentRepeat
-		stats.MostRepeated = lastCmd
-	}
+	c.endRepeat(stats)
 
-	// unique commands
-	stats.UniqueCommands = len(commandCounts)
+	stats.UniqueCommands = c.counts.distinct()
+	stats.TopCommands = topN(c.counts.snapshot(stats), 10)
+	stats.AliasUsage = topN(c.aliasCounts, 10)
 
-	// calculate percentages
 	total := float64(stats.TotalCommands)
+	stats.AvgCommandLen = float64(c.totalLen) / total
+	stats.AliasedPct = float64(stats.AliasedCount) / total * 100
 	stats.SudoPct = float64(stats.SudoCount) / total * 100
-	stats.PipePct = float64(stats.PipeCount) / total * 100
-	stats.AvgCommandLen = float64(totalCmdLen) / total
 
 	if stats.HasTimeData {
 		stats.NightOwlPct = stats.NightOwlPct / total * 100
 		stats.WeekendPct = stats.WeekendPct / total * 100
 		stats.HistorySpan = stats.LastCommand.Sub(stats.FirstCommand)
-		
+
 		days := stats.HistorySpan.Hours() / 24
 		if days > 0 {
 			stats.CommandsPerDay = total / days
 		}
 	}
 
-	// top commands
-	stats.TopCommands = topN(commandCounts, 10)
-
 	// category percentages
 	for cat, count := range stats.Categories {
 		stats.CategoryPct[cat] = float64(count) / total * 100
@@ -244,33 +319,18 @@ func Analyze(data *parser.HistoryData) *Stats {
 			}
 		}
 	}
+}
 
-	// longest streak
-	if len(activeDays) > 0 {
-		stats.LongestStreak, stats.BusiestDay, stats.BusiestDayCount = calculateStreak(activeDays, data.Commands)
-	}
-
-	// favorite directory
-	maxDir := 0
-	for dir, count := range dirCounts {
-		if count > maxDir {
-			maxDir = count
-			stats.FavoriteDir = dir
-			stats.FavoriteDirCount = count
-		}
-	}
-
-	// editor choice
-	maxEditor := 0
-	for editor, count := range editorCounts {
-		if count > maxEditor {
-			maxEditor = count
-			stats.EditorChoice = editor
-			stats.EditorCount = count
+// categoryOf returns the category a base command belongs to, or "" if it has none
+func CategoryOf(baseCmd string) string {
+	for category, commands := range categoryCommands {
+		for _, c := range commands {
+			if baseCmd == c {
+				return category
+			}
 		}
 	}
-
-	return stats
+	return ""
 }
 
 func topN(counts map[string]int, n int) []CommandCount {
@@ -279,8 +339,12 @@ func topN(counts map[string]int, n int) []CommandCount {
 		result = append(result, CommandCount{Command: cmd, Count: count})
 	}
 	
+	// ties break alphabetically so every run ranks the same way
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].Count > result[j].Count
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Command < result[j].Command
 	})
 
 	if len(result) > n {
@@ -289,58 +353,6 @@ func topN(counts map[string]int, n int) []CommandCount {
 	return result
 }
 
-func calculateStreak(activeDays map[string]bool, commands []parser.Command) (longestStreak int, busiestDay time.Time, busiestCount int) {
-	if len(activeDays) == 0 {
-		return 0, time.Time{}, 0
-	}
-
-	// count commands per day
-	dayCounts := make(map[string]int)
-	for _, cmd := range commands {
-		if cmd.HasTime {
-			dayKey := cmd.Timestamp.Format("2006-01-02")
-			dayCounts[dayKey]++
-		}
-	}
-
-	// find busiest day
-	for dayStr, count := range dayCounts {
-		if count > busiestCount {
-			busiestCount = count
-			busiestDay, _ = time.Parse("2006-01-02", dayStr)
-		}
-	}
-
-	// sort days
-	days := make([]string, 0, len(activeDays))
-	for day := range activeDays {
-		days = append(days, day)
-	}
-	sort.Strings(days)
-
-	// calculate streak
-	currentStreak := 1
-	for i := 1; i < len(days); i++ {
-		prevDay, _ := time.Parse("2006-01-02", days[i-1])
-		currDay, _ := time.Parse("2006-01-02", days[i])
-		
-		if currDay.Sub(prevDay).Hours() <= 24 {
-			currentStreak++
-		} else {
-			if currentStreak > longestStreak {
-				longestStreak = currentStreak
-			}
-			currentStreak = 1
-		}
-	}
-	
-	if currentStreak > longestStreak {
-		longestStreak = currentStreak
-	}
-
-	return longestStreak, busiestDay, busiestCount
-}
-
 // getSudoLevel returns a fun label for sudo usage
 func GetSudoLevel(pct float64) string {
 	switch {
@@ -0,0 +1,40 @@
+package analyzer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestBucketsAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data not available: %v", err)
+	}
+	lines := []string{
+		// spring forward, Sunday 2025-03-09: 01:30 EST, then 03:30 EDT
+		timed(time.Date(2025, 3, 9, 6, 30, 0, 0, time.UTC), "ls"),
+		timed(time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC), "ls"),
+		// fall back, Sunday 2025-11-02: 01:30 EDT and 01:30 EST
+		timed(time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC), "ls"),
+		timed(time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC), "ls"),
+		// 23:30 on the 2nd in New York, already the 3rd in UTC
+		timed(time.Date(2025, 11, 3, 4, 30, 0, 0, time.UTC), "ls"),
+	}
+	scanner := parser.NewScanner(strings.NewReader(strings.Join(lines, "\n")), "zsh")
+	scanner.SetLocation(newYork)
+	stats, err := AnalyzeStream(scanner, time.Date(2025, 12, 1, 0, 0, 0, 0, newYork), DefaultOptions())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sunday := stats.HeatMap[time.Sunday]
+	if sunday[1] != 3 || sunday[3] != 1 || sunday[23] != 1 || sunday[2] != 0 {
+		t.Errorf("Sunday hours 1, 2, 3, 23 = %d %d %d %d, want 3 0 1 1", sunday[1], sunday[2], sunday[3], sunday[23])
+	}
+	if got := stats.BusiestDay.Format("2006-01-02"); got != "2025-11-02" || stats.BusiestDayCount != 3 {
+		t.Errorf("busiest day = %s (%d), want 2025-11-02 (3)", got, stats.BusiestDayCount)
+	}
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"sort"
+	"time"
+)
+
+// streak is a run of consecutive active days (Start and End are civil dates)
+type Streak struct {
+	Start time.Time
+	End   time.Time
+	Days  int
+}
+
+// streakTracker counts commands per calendar day. now decides whether the
+// latest streak is still alive (active today or yesterday)
+type streakTracker struct {
+	days map[time.Time]int
+	now  time.Time
+}
+
+func newStreakTracker(now time.Time) *streakTracker {
+	return &streakTracker{days: make(map[time.Time]int), now: now}
+}
+
+func (s *streakTracker) Add(e *Event) {
+	cmd := e.Cmd
+	if cmd.HasTime {
+		s.days[civilDay(cmd.Timestamp)]++
+	}
+}
+
+// finish derives streaks, breaks and the busiest day
+func (s *streakTracker) Finish(stats *Stats) {
+	now := s.now
+	if len(s.days) == 0 {
+		return
+	}
+
+	days := make([]time.Time, 0, len(s.days))
+	for day, count := range s.days {
+		days = append(days, day)
+		if count > stats.BusiestDayCount || (count == stats.BusiestDayCount && day.Before(stats.BusiestDay)) {
+			stats.BusiestDay = day
+			stats.BusiestDayCount = count
+		}
+	}
+	sort.Slice(days, func(i, j int) bool { return days[i].Before(days[j]) })
+	stats.ActiveDays = len(days)
+
+	current := Streak{Start: days[0], End: days[0], Days: 1}
+	for _, day := range days[1:] {
+		gap := daysBetween(current.End, day)
+		if gap == 1 {
+			current.End = day
+			current.Days++
+			continue
+		}
+
+		stats.Streaks = append(stats.Streaks, current)
+		// gap-1 whole days without a single command
+		if gap-1 > stats.LongestBreak {
+			stats.LongestBreak = gap - 1
+			stats.LongestBreakStart = current.End.AddDate(0, 0, 1)
+			stats.LongestBreakEnd = day.AddDate(0, 0, -1)
+		}
+		current = Streak{Start: day, End: day, Days: 1}
+	}
+	stats.Streaks = append(stats.Streaks, current)
+
+	// earliest record wins ties
+	for _, streak := range stats.Streaks {
+		if streak.Days > stats.LongestStreak {
+			stats.LongestStreak = streak.Days
+			stats.LongestStreakStart = streak.Start
+			stats.LongestStreakEnd = streak.End
+		}
+	}
+
+	// alive if the last active day is today or yesterday. a history ending
+	// in the future (clock skew, wrong zone) has no current streak
+	if !now.IsZero() {
+		if since := daysBetween(current.End, civilDay(now)); 0 <= since && since <= 1 {
+			stats.CurrentStreak = current.Days
+		}
+	}
+}
+
+// civilDay returns the calendar date of t in its own zone, as midnight UTC.
+// day arithmetic on these values is exact, unlike on local midnights that
+// are 23 or 25 hours apart around DST changes
+func civilDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween counts calendar days from a to b (both civil days)
+func daysBetween(a, b time.Time) int {
+	return int(b.Sub(a).Hours() / 24)
+}
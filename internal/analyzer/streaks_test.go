@@ -0,0 +1,110 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+// date returns a civil date in 2025
+func date(month time.Month, day int) time.Time {
+	return time.Date(2025, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// streaksOn runs a streakTracker over one command at noon on each day
+func streaksOn(now time.Time, days ...time.Time) *Stats {
+	s := newStreakTracker(now)
+	for _, day := range days {
+		s.Add(commandAt(day.Add(12 * time.Hour)))
+	}
+	stats := &Stats{}
+	s.Finish(stats)
+	return stats
+}
+
+func TestCurrentStreak(t *testing.T) {
+	days := []time.Time{date(3, 1), date(3, 2), date(3, 3)}
+	tests := []struct {
+		name string
+		now  time.Time
+		want int
+	}{
+		{"active today", date(3, 3).Add(20 * time.Hour), 3},
+		{"active yesterday", date(3, 4).Add(9 * time.Hour), 3},
+		{"broken", date(3, 5), 0},
+		{"history in the future", date(2, 27), 0},
+		{"history ends tomorrow", date(3, 2), 0},
+		{"no now", time.Time{}, 0},
+	}
+	for _, tt := range tests {
+		if got := streaksOn(tt.now, days...).CurrentStreak; got != tt.want {
+			t.Errorf("%s: CurrentStreak = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLongestStreak(t *testing.T) {
+	tests := []struct {
+		name       string
+		days       []time.Time
+		want       int
+		start, end time.Time
+	}{
+		{"single day", []time.Time{date(3, 1)}, 1, date(3, 1), date(3, 1)},
+		{"run", []time.Time{date(3, 1), date(3, 2), date(3, 3), date(3, 5)}, 3, date(3, 1), date(3, 3)},
+		{"later run", []time.Time{date(3, 1), date(3, 3), date(3, 4)}, 2, date(3, 3), date(3, 4)},
+		// the earliest of equally long streaks wins
+		{"tie", []time.Time{date(3, 1), date(3, 2), date(3, 4), date(3, 5)}, 2, date(3, 1), date(3, 2)},
+		{"across months", []time.Time{date(2, 27), date(2, 28), date(3, 1)}, 3, date(2, 27), date(3, 1)},
+		// a DST change doesn't make a day 23 or 25 hours long here
+		{"across DST", []time.Time{date(3, 8), date(3, 9), date(3, 10)}, 3, date(3, 8), date(3, 10)},
+	}
+	for _, tt := range tests {
+		stats := streaksOn(time.Time{}, tt.days...)
+		if stats.LongestStreak != tt.want || !stats.LongestStreakStart.Equal(tt.start) || !stats.LongestStreakEnd.Equal(tt.end) {
+			t.Errorf("%s: longest = %d (%s to %s), want %d (%s to %s)", tt.name,
+				stats.LongestStreak, stats.LongestStreakStart.Format("01-02"), stats.LongestStreakEnd.Format("01-02"),
+				tt.want, tt.start.Format("01-02"), tt.end.Format("01-02"))
+		}
+	}
+}
+
+func TestStreakBreaks(t *testing.T) {
+	stats := streaksOn(time.Time{},
+		date(3, 1), date(3, 2),
+		date(3, 4), // 1 day off
+		date(3, 9), // 4 days off
+		date(3, 12), date(3, 13),
+	)
+	if stats.LongestBreak != 4 || !stats.LongestBreakStart.Equal(date(3, 5)) || !stats.LongestBreakEnd.Equal(date(3, 8)) {
+		t.Errorf("longest break = %d (%v to %v), want 4 (03-05 to 03-08)", stats.LongestBreak, stats.LongestBreakStart, stats.LongestBreakEnd)
+	}
+	want := []Streak{
+		{date(3, 1), date(3, 2), 2},
+		{date(3, 4), date(3, 4), 1},
+		{date(3, 9), date(3, 9), 1},
+		{date(3, 12), date(3, 13), 2},
+	}
+	if len(stats.Streaks) != len(want) {
+		t.Fatalf("Streaks = %+v, want %+v", stats.Streaks, want)
+	}
+	for i := range want {
+		if stats.Streaks[i] != want[i] {
+			t.Errorf("streak %d = %+v, want %+v", i, stats.Streaks[i], want[i])
+		}
+	}
+	if stats.ActiveDays != 6 {
+		t.Errorf("ActiveDays = %d, want 6", stats.ActiveDays)
+	}
+
+	if stats := streaksOn(time.Time{}, date(3, 1), date(3, 2)); stats.LongestBreak != 0 {
+		t.Errorf("LongestBreak = %d without a break", stats.LongestBreak)
+	}
+}
+
+func TestBusiestDay(t *testing.T) {
+	stats := streaksOn(time.Time{}, date(3, 2), date(3, 1), date(3, 2), date(3, 1), date(3, 3))
+	// ties go to the earlier day
+	if !stats.BusiestDay.Equal(date(3, 1)) || stats.BusiestDayCount != 2 {
+		t.Errorf("busiest day = %v (%d), want 03-01 (2)", stats.BusiestDay, stats.BusiestDayCount)
+	}
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// event is one command as the accumulators see it
+type Event struct {
+	Cmd        *parser.Command
+	Base       string     // base command (sudo, env vars and wrappers skipped)
+	Pipelines  [][]string // stages of each pipeline in the line
+	NewSession bool       // first command after an idle gap
+}
+
+// newEvent works out what the accumulators share once per command, so
+// none of them has to split the line again. with hashHosts every tracker
+// sees the line with its hostnames already replaced by digests
+func newEvent(cmd *parser.Command, hashHosts bool) Event {
+	e := Event{
+		Cmd:       cmd,
+		Base:      parser.GetBaseCommand(cmd),
+		Pipelines: parser.SplitPipelines(cmd.Raw),
+	}
+	if hashHosts {
+		if hidden := hideHosts(cmd, e.Base, e.Pipelines); hidden != cmd {
+			e.Cmd = hidden
+			e.Pipelines = parser.SplitPipelines(hidden.Raw)
+		}
+	}
+	return e
+}
+
+// accumulator consumes commands one at a time and writes its results into
+// Stats once the stream ends. accumulators only keep aggregates, so memory
+// depends on how varied a history is, not on how long it is
+type Accumulator interface {
+	Add(e *Event)
+	Finish(stats *Stats)
+}
+
+// analyzer computes Stats from a stream of commands
+type Analyzer struct {
+	stats        *Stats
+	sessions     *sessionTracker
+	accumulators []Accumulator
+	hashHosts    bool
+	finished     bool
+}
+
+// newAnalyzer prepares an analysis. now is when the history was read; it
+// decides whether the latest streak is still alive
+func NewAnalyzer(opts Options, now time.Time) *Analyzer {
+	stats := &Stats{
+		Categories:  make(map[string]int),
+		CategoryPct: make(map[string]float64),
+	}
+	// base command -> uses, shared by the trackers that compare commands
+	counts := newTopCounts[string](maxTrackedKeys)
+
+	return &Analyzer{
+		stats:    stats,
+		sessions: newSessionTracker(opts.SessionGap),
+		accumulators: []Accumulator{
+			newCommandTracker(stats, counts),
+			newWorkflowTracker(),
+			newTypoTracker(counts),
+			newAliasTracker(counts, opts.NameInUse),
+			newDirTracker(),
+			newRiskTracker(opts.RiskRules),
+			newStreakTracker(now),
+			newAdoptionTracker(counts),
+			newTrendTracker(),
+			newFlagTracker(),
+			newGitTracker(),
+			newInfraTracker(),
+			newSSHTracker(),
+			newPackageTracker(!opts.SkipLedger),
+			newLanguageTracker(),
+			newEditorTracker(),
+			newPipeTracker(),
+		},
+		hashHosts: opts.HashHosts,
+	}
+}
+
+// add feeds one command to every accumulator. cmd is not retained
+func (a *Analyzer) Add(cmd *parser.Command) {
+	a.stats.TotalCommands++
+	e := newEvent(cmd, a.hashHosts)
+	e.NewSession = a.sessions.add(cmd)
+	for _, acc := range a.accumulators {
+		acc.Add(&e)
+	}
+}
+
+// finish completes the analysis and returns the stats
+func (a *Analyzer) Finish() *Stats {
+	if a.finished || a.stats.TotalCommands == 0 {
+		return a.stats
+	}
+	a.finished = true
+
+	a.sessions.finish(a.stats)
+	for _, acc := range a.accumulators {
+		acc.Finish(a.stats)
+	}
+	return a.stats
+}
+
+// analyzeStream computes all statistics from a stream of commands without
+// holding the history in memory
+func AnalyzeStream(stream parser.CommandStream, now time.Time, opts Options) (*Stats, error) {
+	a := NewAnalyzer(opts, now)
+	for stream.Scan() {
+		a.Add(stream.Command())
+	}
+	return a.Finish(), stream.Err()
+}
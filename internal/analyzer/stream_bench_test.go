@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// syntheticCommands mixes frequent commands with ones that are unique on
+// every line, so the maps keyed by command text keep seeing new keys
+var syntheticCommands = []string{
+	"git status",
+	"git commit -m \"fix %d\"",
+	"git checkout feature-%d",
+	"cd ~/code/project%d",
+	"vim src/file%d.go",
+	"ls -la",
+	"docker ps | grep api | awk '{print $1}'",
+	"cat log%d.txt | grep ERROR | sort | uniq -c | sort -rn | head",
+	"npm install package-%d",
+	"kubectl get pods -n ns%d",
+	"ssh host%d.example.com",
+	"go test ./...",
+	"echo %d | sha256sum | cut -c1-8",
+}
+
+// historyReader generates zsh extended history lines on the fly, so the
+// input itself never sits in memory
+type historyReader struct {
+	rng     *rand.Rand
+	lines   int
+	written int
+	clock   int64
+	pending []byte
+}
+
+func newHistoryReader(lines int) *historyReader {
+	return &historyReader{rng: rand.New(rand.NewSource(1)), lines: lines, clock: 1704067200}
+}
+
+func (h *historyReader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(h.pending) == 0 {
+			if h.written == h.lines {
+				break
+			}
+			h.written++
+			h.clock += int64(h.rng.Intn(600))
+			command := syntheticCommands[h.rng.Intn(len(syntheticCommands))]
+			if h.rng.Intn(4) == 0 {
+				command = fmt.Sprintf(command, h.written)
+			} else {
+				command = fmt.Sprintf(command, h.rng.Intn(20))
+			}
+			h.pending = fmt.Appendf(h.pending[:0], ": %d:0;%s\n", h.clock, command)
+		}
+		copied := copy(p[n:], h.pending)
+		h.pending = h.pending[copied:]
+		n += copied
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// benchmarkStream analyzes a generated history and reports the peak live
+// heap seen while streaming. it should level off as the history grows
+func benchmarkStream(b *testing.B, lines int) {
+	opts := DefaultOptions()
+	// as for the terminal report, which doesn't export the package ledger
+	opts.SkipLedger = true
+	for b.Loop() {
+		runtime.GC()
+		var mem runtime.MemStats
+		peak := uint64(0)
+
+		scanner := parser.NewScanner(newHistoryReader(lines), "zsh")
+		a := NewAnalyzer(opts, time.Unix(1735689600, 0))
+		for i := 0; scanner.Scan(); i++ {
+			a.Add(scanner.Command())
+			if i%100_000 == 0 {
+				runtime.GC()
+				runtime.ReadMemStats(&mem)
+				peak = max(peak, mem.HeapAlloc)
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			b.Fatal(err)
+		}
+		runtime.GC()
+		runtime.ReadMemStats(&mem)
+		peak = max(peak, mem.HeapAlloc)
+
+		if stats := a.Finish(); stats.TotalCommands != lines {
+			b.Fatalf("analyzed %d commands, want %d", stats.TotalCommands, lines)
+		}
+		b.ReportMetric(float64(peak)/(1<<20), "peak-MB")
+	}
+}
+
+func BenchmarkStream100k(b *testing.B) { benchmarkStream(b, 100_000) }
+
+func BenchmarkStream1M(b *testing.B) { benchmarkStream(b, 1_000_000) }
+
+func BenchmarkStream3M(b *testing.B) { benchmarkStream(b, 3_000_000) }
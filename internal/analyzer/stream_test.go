@@ -0,0 +1,58 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestAnalyzeStreamMatchesInMemory(t *testing.T) {
+	const lines = 20_000
+	now := time.Unix(1735689600, 0).UTC()
+	opts := DefaultOptions()
+
+	// the history loaded into memory first, as -explore does
+	data := &parser.HistoryData{Shell: "zsh", ParsedAt: now}
+	scanner := parser.NewScanner(newHistoryReader(lines), "zsh")
+	for scanner.Scan() {
+		data.Commands = append(data.Commands, *scanner.Command())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatal(err)
+	}
+	data.SetLocation(time.UTC)
+	data.ParsedAt = now
+	loaded := AnalyzeWithOptions(data, opts)
+
+	// the same history streamed straight from the reader
+	scanner = parser.NewScanner(newHistoryReader(lines), "zsh")
+	scanner.SetLocation(time.UTC)
+	streamed, err := AnalyzeStream(scanner, now, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if streamed.TotalCommands != lines {
+		t.Fatalf("streamed %d commands, want %d", streamed.TotalCommands, lines)
+	}
+	if !reflect.DeepEqual(loaded, streamed) {
+		t.Error("streaming and in-memory analysis differ")
+		lv, sv := reflect.ValueOf(*loaded), reflect.ValueOf(*streamed)
+		for i := range lv.NumField() {
+			if !reflect.DeepEqual(lv.Field(i).Interface(), sv.Field(i).Interface()) {
+				t.Errorf("%s: in memory %v, streamed %v", lv.Type().Field(i).Name, lv.Field(i), sv.Field(i))
+			}
+		}
+	}
+}
+
+func TestAnalyzerFinishIsIdempotent(t *testing.T) {
+	a := NewAnalyzer(DefaultOptions(), testNow)
+	a.Add(parser.ParseStage("git status"))
+	first := a.Finish()
+	if second := a.Finish(); second != first || second.TotalCommands != 1 || len(second.TopCommands) != 1 {
+		t.Errorf("second Finish = %+v, want the same stats", second)
+	}
+}
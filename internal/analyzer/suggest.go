@@ -0,0 +1,220 @@
+package analyzer
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// aliasSuggestion is a proposed alias (or function) for a repeated command
+type AliasSuggestion struct {
+	Name     string // proposed short name
+	Command  string // what it expands to
+	Count    int    // times the expansion was typed
+	Saved    int    // estimated keystrokes saved over the history
+	Function bool   // compound commands need a shell function
+}
+
+const (
+	minAliasLen     = 10 // shorter commands aren't worth an alias
+	minAliasUses    = 5
+	maxPrefixTokens = 8
+	topAliasCount   = 8
+	// a longer pattern used this often replaces its shorter prefix
+	aliasCoverRatio = 0.8
+)
+
+// options whose value is free text, like a commit message
+var freeTextFlags = map[string]bool{
+	"-m": true, "-am": true, "--message": true, "--title": true, "--body": true,
+}
+
+// shell builtins and reserved words of zsh, bash and fish. an alias with
+// one of these names would break scripts and muscle memory alike
+var shellBuiltins = func() map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Fields(`
+		alias bg bind break builtin caller case cd command compgen complete compopt
+		continue coproc declare dirs disown do done echo elif else enable esac eval
+		exec exit export false fc fg fi for function getopts hash help history if in
+		jobs kill let local logout mapfile popd printf pushd pwd read readarray
+		readonly return select set shift shopt source suspend test then time times
+		trap true type typeset ulimit umask unalias unset until wait while
+		autoload bindkey bye chdir emulate functions integer noglob print pushln
+		rehash repeat sched setopt unfunction unhash unsetopt whence where which
+		zle zmodload zparseopts zstyle foreach end nocorrect
+		and or not begin abbr argparse block contains count emit funced funcsave
+		math random realpath set_color status string switch`) {
+		names[name] = true
+	}
+	return names
+}()
+
+// aliasTracker counts repeated command lines and argument prefixes
+type aliasTracker struct {
+	prefixes  *topCounts[string]
+	compounds *topCounts[string]
+	counts    *topCounts[string]     // base command -> uses, filled by the analyzer
+	inUse     func(name string) bool // names the shell already resolves, may be nil
+}
+
+func newAliasTracker(counts *topCounts[string], inUse func(name string) bool) *aliasTracker {
+	return &aliasTracker{
+		prefixes:  newTopCounts[string](maxTrackedKeys),
+		compounds: newTopCounts[string](maxTrackedKeys),
+		counts:    counts,
+		inUse:     inUse,
+	}
+}
+
+func (a *aliasTracker) Add(e *Event) {
+	cmd := e.Cmd
+	// already typed through an alias
+	if cmd.Alias != "" {
+		return
+	}
+
+	tokens := append([]string{cmd.Command}, cmd.Args...)
+	line := strings.Join(tokens, " ")
+	if len(line) < minAliasLen {
+		return
+	}
+
+	// pipelines and chains only make sense as a whole. quoted or escaped
+	// operators (grep 'a|b') and redirects (2>&1) don't make a compound
+	if len(e.Pipelines) > 1 || (len(e.Pipelines) == 1 && len(e.Pipelines[0]) > 1) {
+		a.compounds.add(line)
+		return
+	}
+
+	// count every prefix so "git commit -m <msg>" patterns surface even
+	// though each message is different. free text never becomes part of
+	// an alias, so prefixes stop in front of it
+	reusable := reusableTokens(tokens)
+	for k := 2; k <= min(reusable, maxPrefixTokens); k++ {
+		prefix := strings.Join(tokens[:k], " ")
+		if len(prefix) >= minAliasLen {
+			a.prefixes.add(prefix)
+		}
+	}
+	if reusable == len(tokens) && len(tokens) > maxPrefixTokens {
+		a.prefixes.add(line)
+	}
+}
+
+// reusableTokens returns how many leading tokens can go into an alias: the
+// ones before a quoted argument or the value of a free text option
+func reusableTokens(tokens []string) int {
+	for i, token := range tokens {
+		if strings.HasPrefix(token, `"`) || strings.HasPrefix(token, "'") {
+			return i
+		}
+		if freeTextFlags[token] {
+			return i + 1
+		}
+		if name, _, found := strings.Cut(token, "="); found && freeTextFlags[name] {
+			return i
+		}
+	}
+	return len(tokens)
+}
+
+func (a *aliasTracker) Finish(stats *Stats) {
+	commandCounts := a.counts.snapshot(stats)
+	prefixes := a.prefixes.snapshot(stats)
+	// the most used one-token extension of every prefix; any longer pattern
+	// is used at most as often as its direct extension
+	longestChild := make(map[string]int)
+	for prefix, count := range prefixes {
+		if i := strings.LastIndex(prefix, " "); i > 0 {
+			parent := prefix[:i]
+			longestChild[parent] = max(longestChild[parent], count)
+		}
+	}
+
+	var candidates []AliasSuggestion
+	for prefix, count := range prefixes {
+		// skip prefixes whose uses are mostly a longer pattern
+		if count < minAliasUses || float64(longestChild[prefix]) >= aliasCoverRatio*float64(count) {
+			continue
+		}
+		candidates = append(candidates, AliasSuggestion{Command: prefix, Count: count})
+	}
+	for line, count := range a.compounds.snapshot(stats) {
+		if count >= minAliasUses {
+			candidates = append(candidates, AliasSuggestion{Command: line, Count: count, Function: true})
+		}
+	}
+
+	for i := range candidates {
+		candidates[i].Saved = (len(candidates[i].Command) - 3) * candidates[i].Count
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].Saved != candidates[j].Saved {
+			return candidates[i].Saved > candidates[j].Saved
+		}
+		return candidates[i].Command < candidates[j].Command
+	})
+
+	taken := make(map[string]bool)
+	for _, c := range candidates {
+		if len(stats.AliasSuggestions) >= topAliasCount {
+			break
+		}
+		name := aliasName(c.Command, func(n string) bool {
+			return taken[n] || knownTools[n] || shellBuiltins[n] || commandCounts[n] > 0 ||
+				(a.inUse != nil && a.inUse(n))
+		})
+		if name == "" {
+			continue
+		}
+		taken[name] = true
+		c.Name = name
+		c.Saved = (len(c.Command) - len(name)) * c.Count
+		stats.AliasSuggestions = append(stats.AliasSuggestions, c)
+	}
+
+	// re-rank with the real name lengths
+	sort.SliceStable(stats.AliasSuggestions, func(i, j int) bool {
+		return stats.AliasSuggestions[i].Saved > stats.AliasSuggestions[j].Saved
+	})
+}
+
+// aliasName builds a short name from token initials ("git commit -m" -> "gcm"),
+// falling back to longer or numbered names when the short one is taken
+func aliasName(command string, taken func(string) bool) string {
+	var initials strings.Builder
+	var last string
+	for _, token := range strings.Fields(command) {
+		token = strings.TrimLeft(token, "-./~\"'")
+		for _, r := range token {
+			if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+				initials.WriteRune(r | 0x20)
+				last = token
+				break
+			}
+		}
+		if initials.Len() >= 5 {
+			break
+		}
+	}
+
+	name := initials.String()
+	if len(name) < 2 {
+		return ""
+	}
+	if !taken(name) {
+		return name
+	}
+	if len(last) > 1 {
+		if longer := name + strings.ToLower(last[1:2]); !taken(longer) {
+			return longer
+		}
+	}
+	for i := 2; i < 10; i++ {
+		if numbered := name + strconv.Itoa(i); !taken(numbered) {
+			return numbered
+		}
+	}
+	return ""
+}
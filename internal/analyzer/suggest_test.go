@@ -0,0 +1,159 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestReusableTokens(t *testing.T) {
+	tests := []struct {
+		line string
+		want int
+	}{
+		{"git status", 2},
+		{"git commit -m 'add handler'", 3},
+		{"git commit -am fix", 3},
+		{"git commit --message=fix", 2},
+		{`gh pr create --title "Fix login" --body x`, 4},
+		{`grep -r "TODO" src`, 2},
+		{"kubectl get pods -n staging", 5},
+	}
+	for _, tt := range tests {
+		if got := reusableTokens(strings.Fields(tt.line)); got != tt.want {
+			t.Errorf("reusableTokens(%q) = %d, want %d", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestAliasName(t *testing.T) {
+	none := func(string) bool { return false }
+	tests := []struct {
+		command string
+		taken   func(string) bool
+		want    string
+	}{
+		{"git commit -m", none, "gcm"},
+		{"kubectl get pods -n staging", none, "kgpns"},
+		{"cd ~/code/api", none, "cc"},
+		{"git status", func(n string) bool { return n == "gs" }, "gst"},
+		{"git status", func(n string) bool { return n == "gs" || n == "gst" }, "gs2"},
+	}
+	for _, tt := range tests {
+		if got := aliasName(tt.command, tt.taken); got != tt.want {
+			t.Errorf("aliasName(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestAliasSuggestions(t *testing.T) {
+	var lines []string
+	for i := range 6 {
+		lines = append(lines,
+			fmt.Sprintf("git commit -m 'change %d'", i),
+			"git commit -m 'add handler'",
+			"kubectl get pods -n staging",
+			"ls",
+		)
+	}
+	stats := analyzeLines(t, lines...)
+
+	got := make(map[string]AliasSuggestion)
+	for _, s := range stats.AliasSuggestions {
+		got[s.Command] = s
+	}
+	if s, ok := got["git commit -m"]; !ok || s.Count != 12 || s.Name != "gcm" {
+		t.Errorf("git commit -m suggestion = %+v, want gcm used 12 times", s)
+	}
+	if _, ok := got["kubectl get pods -n staging"]; !ok {
+		t.Error("kubectl get pods -n staging not suggested")
+	}
+	for command := range got {
+		if strings.Contains(command, "'") {
+			t.Errorf("suggested an alias for free text: %q", command)
+		}
+		// covered by the full kubectl pattern, which is always typed with it
+		if command == "kubectl get pods" {
+			t.Errorf("suggested %q next to the longer pattern", command)
+		}
+	}
+	if _, ok := got["ls"]; ok {
+		t.Error("suggested an alias for a short command")
+	}
+}
+
+func TestAliasSuggestionSavings(t *testing.T) {
+	var lines []string
+	for range 6 {
+		lines = append(lines, "docker compose up -d")
+	}
+	stats := analyzeLines(t, lines...)
+	if len(stats.AliasSuggestions) == 0 {
+		t.Fatal("no suggestion for a repeated long command")
+	}
+	if s := stats.AliasSuggestions[0]; s.Command != "docker compose up -d" || s.Saved != (len(s.Command)-len(s.Name))*6 {
+		t.Errorf("suggestion = %+v", s)
+	}
+}
+
+func TestAliasNamesAvoidShellNames(t *testing.T) {
+	var lines []string
+	for range 6 {
+		lines = append(lines, "cd ~/code/api", "crane digest", "docker compose up")
+	}
+	opts := DefaultOptions()
+	// cc is on PATH, dcu an alias of the user's
+	opts.NameInUse = func(name string) bool { return name == "cc" || name == "dcu" }
+	stats := analyzeLinesWith(t, opts, lines...)
+
+	want := map[string]string{
+		"cd ~/code/api":     "cco",
+		"crane digest":      "cdi", // cd is a builtin
+		"docker compose up": "dcup",
+	}
+	got := make(map[string]string)
+	for _, s := range stats.AliasSuggestions {
+		got[s.Command] = s.Name
+	}
+	for command, name := range want {
+		if got[command] != name {
+			t.Errorf("alias for %q = %q, want %q", command, got[command], name)
+		}
+	}
+}
+
+func TestAliasCompounds(t *testing.T) {
+	var lines []string
+	for range 6 {
+		lines = append(lines,
+			"git fetch && git status",
+			"make build 2>&1",
+			`grep -E a\|b app.log`,
+		)
+	}
+	stats := analyzeLines(t, lines...)
+
+	got := make(map[string]AliasSuggestion)
+	for _, s := range stats.AliasSuggestions {
+		got[s.Command] = s
+	}
+	tests := []struct {
+		command  string
+		function bool
+	}{
+		{"git fetch && git status", true},
+		// redirects and escaped operators are one simple command
+		{"make build 2>&1", false},
+		{`grep -E a\|b app.log`, false},
+	}
+	for _, tt := range tests {
+		s, ok := got[tt.command]
+		if !ok {
+			t.Errorf("%q not suggested", tt.command)
+			continue
+		}
+		if s.Function != tt.function {
+			t.Errorf("%q Function = %v, want %v", tt.command, s.Function, tt.function)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package analyzer
+
+import (
+	"time"
+)
+
+// monthTrend summarizes one calendar month (2006-01)
+type MonthTrend struct {
+	Month       string
+	Count       int
+	TopCommand  string
+	TopCount    int
+	CategoryPct map[string]float64 // share of the month's commands
+}
+
+// weekCount is the number of commands in the week starting Monday Start
+type WeekCount struct {
+	Start time.Time
+	Count int
+}
+
+// base commands tracked per month; only the month's top command is reported
+const maxMonthlyCommands = 1 << 10
+
+// trendTracker buckets commands by month and week
+type trendTracker struct {
+	months     map[string]int
+	weeks      map[time.Time]int
+	commands   map[string]*topCounts[string] // month -> base command -> count
+	categories map[string]map[string]int     // month -> category -> count, categories are a fixed set
+}
+
+func newTrendTracker() *trendTracker {
+	return &trendTracker{
+		months:     make(map[string]int),
+		weeks:      make(map[time.Time]int),
+		commands:   make(map[string]*topCounts[string]),
+		categories: make(map[string]map[string]int),
+	}
+}
+
+func (t *trendTracker) Add(e *Event) {
+	cmd, baseCmd := e.Cmd, e.Base
+	if !cmd.HasTime {
+		return
+	}
+	month := cmd.Timestamp.Format("2006-01")
+	t.months[month]++
+	t.weeks[weekStart(cmd.Timestamp)]++
+
+	if t.commands[month] == nil {
+		t.commands[month] = newTopCounts[string](maxMonthlyCommands)
+		t.categories[month] = make(map[string]int)
+	}
+	t.commands[month].add(baseCmd)
+	if category := CategoryOf(baseCmd); category != "" {
+		t.categories[month][category]++
+	}
+}
+
+func (t *trendTracker) Finish(stats *Stats) {
+	if len(t.months) == 0 {
+		return
+	}
+
+	// every month between the first and last, so quiet months show as gaps
+	first := civilDay(stats.FirstCommand)
+	last := civilDay(stats.LastCommand)
+	month := time.Date(first.Year(), first.Month(), 1, 0, 0, 0, 0, time.UTC)
+	for !month.After(last) {
+		key := month.Format("2006-01")
+		trend := MonthTrend{Month: key, Count: t.months[key], CategoryPct: make(map[string]float64)}
+		var commands map[string]int
+		if t.commands[key] != nil {
+			commands = t.commands[key].snapshot(stats)
+		}
+		for cmd, count := range commands {
+			if count > trend.TopCount || (count == trend.TopCount && cmd < trend.TopCommand) {
+				trend.TopCommand, trend.TopCount = cmd, count
+			}
+		}
+		for category, count := range t.categories[key] {
+			trend.CategoryPct[category] = float64(count) / float64(trend.Count) * 100
+		}
+		stats.MonthlyTrend = append(stats.MonthlyTrend, trend)
+		month = month.AddDate(0, 1, 0)
+	}
+
+	for week := weekStart(first); !week.After(last); week = week.AddDate(0, 0, 7) {
+		stats.WeeklyCounts = append(stats.WeeklyCounts, WeekCount{Start: week, Count: t.weeks[week]})
+	}
+}
+
+// weekStart returns the Monday (as a civil day) of the week containing t
+func weekStart(t time.Time) time.Time {
+	day := civilDay(t)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
@@ -0,0 +1,71 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		t    time.Time
+		want time.Time
+	}{
+		// Wednesday -> Monday
+		{time.Date(2025, 3, 5, 18, 0, 0, 0, time.UTC), date(3, 3)},
+		{time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC), date(3, 3)},
+		// Sunday belongs to the week that started six days earlier
+		{time.Date(2025, 3, 9, 23, 59, 0, 0, time.UTC), date(3, 3)},
+		// across a month
+		{time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC), date(2, 24)},
+	}
+	for _, tt := range tests {
+		if got := weekStart(tt.t); !got.Equal(tt.want) {
+			t.Errorf("weekStart(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestMonthlyTrend(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC), "git status"),
+		timed(time.Date(2025, 1, 11, 9, 0, 0, 0, time.UTC), "git push"),
+		timed(time.Date(2025, 1, 12, 9, 0, 0, 0, time.UTC), "ls"),
+		timed(time.Date(2025, 1, 13, 9, 0, 0, 0, time.UTC), "my-script"),
+		// nothing in February
+		timed(time.Date(2025, 3, 2, 9, 0, 0, 0, time.UTC), "ls"),
+	)
+
+	if len(stats.MonthlyTrend) != 3 {
+		t.Fatalf("MonthlyTrend has %d months, want Jan, Feb and Mar", len(stats.MonthlyTrend))
+	}
+	jan, feb, mar := stats.MonthlyTrend[0], stats.MonthlyTrend[1], stats.MonthlyTrend[2]
+	if jan.Month != "2025-01" || jan.Count != 4 || jan.TopCommand != "git" || jan.TopCount != 2 {
+		t.Errorf("January = %+v", jan)
+	}
+	if jan.CategoryPct["Git"] != 50 {
+		t.Errorf("January git share = %v%%, want 50%%", jan.CategoryPct["Git"])
+	}
+	if feb.Month != "2025-02" || feb.Count != 0 || feb.TopCommand != "" {
+		t.Errorf("February = %+v, want an empty month", feb)
+	}
+	if mar.Month != "2025-03" || mar.Count != 1 || mar.TopCommand != "ls" {
+		t.Errorf("March = %+v", mar)
+	}
+}
+
+func TestWeeklyCounts(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC), "ls"),  // Monday
+		timed(time.Date(2025, 3, 9, 22, 0, 0, 0, time.UTC), "ls"), // Sunday, same week
+		timed(time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC), "ls"),
+	)
+	want := []WeekCount{{date(3, 3), 2}, {date(3, 10), 0}, {date(3, 17), 1}}
+	if len(stats.WeeklyCounts) != len(want) {
+		t.Fatalf("WeeklyCounts = %+v, want %+v", stats.WeeklyCounts, want)
+	}
+	for i := range want {
+		if !stats.WeeklyCounts[i].Start.Equal(want[i].Start) || stats.WeeklyCounts[i].Count != want[i].Count {
+			t.Errorf("week %d = %+v, want %+v", i, stats.WeeklyCounts[i], want[i])
+		}
+	}
+}
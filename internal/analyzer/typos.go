@@ -0,0 +1,190 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+)
+
+// typo is a mistyped command and what the user most likely meant
+type Typo struct {
+	Typo     string
+	Intended string
+	Count    int
+}
+
+const (
+	// how many following commands count as "nearby" for a correction
+	typoWindow = 3
+	// typos seen at least this often deserve an alias
+	typoAliasMin = 3
+	topTypoCount = 5
+)
+
+// well-known tools a typo can be measured against, on top of categoryCommands
+var extraKnownTools = []string{
+	"python", "python3", "node", "deno", "bun", "ruby", "java", "make", "cmake",
+	"clear", "exit", "history", "echo", "export", "source", "sudo", "man", "which",
+	"kill", "top", "htop", "ps", "du", "df", "tar", "zip", "unzip", "open", "vi",
+	"sed", "awk", "sort", "uniq", "wc", "xargs", "jq", "tmux", "screen", "watch",
+	"npx", "rustc", "gcc", "clang", "diff", "env", "alias", "type", "whoami",
+	"nvm", "uv", "gem", "tsc", "mvn", "ip", "ss", "dig", "host", "nmap", "zsh",
+	"bash", "sh", "fish", "cal", "bc", "dd", "id", "su", "tr", "cut", "tee",
+	"pv", "lsof", "free", "uname", "date", "file", "stat", "mount", "sleep",
+	"aws", "gcloud", "az", "terraform", "pulumi", "ansible", "vagrant", "packer",
+}
+
+var knownTools = func() map[string]bool {
+	known := make(map[string]bool)
+	for _, commands := range categoryCommands {
+		for _, c := range commands {
+			known[c] = true
+		}
+	}
+	for _, c := range extraKnownTools {
+		known[c] = true
+	}
+	return known
+}()
+
+// typoTracker remembers which odd commands were quickly followed by a
+// similar command, i.e. the user retyped it correctly
+type typoTracker struct {
+	window      []string
+	corrections *topCounts[[2]string] // typo, fix -> times retyped nearby
+	counts      *topCounts[string]    // base command -> uses, filled by the analyzer
+}
+
+func newTypoTracker(counts *topCounts[string]) *typoTracker {
+	return &typoTracker{corrections: newTopCounts[[2]string](maxTrackedKeys), counts: counts}
+}
+
+func (t *typoTracker) Add(e *Event) {
+	baseCmd, newSession := e.Base, e.NewSession
+	if newSession {
+		t.window = t.window[:0]
+	}
+	for _, prev := range t.window {
+		if prev != baseCmd && plausibleTypo(prev) && isTypoOf(prev, baseCmd) {
+			t.corrections.add([2]string{prev, baseCmd})
+		}
+	}
+	t.window = append(t.window, baseCmd)
+	if len(t.window) > typoWindow {
+		t.window = t.window[1:]
+	}
+}
+
+func (t *typoTracker) Finish(stats *Stats) {
+	commandCounts := t.counts.snapshot(stats)
+	intended := make(map[string]string)
+
+	// retyped nearby: the strongest correction wins
+	best := make(map[string]int)
+	for pair, count := range t.corrections.snapshot(stats) {
+		typo, fix := pair[0], pair[1]
+		if count > best[typo] || (count == best[typo] && fix < intended[typo]) {
+			best[typo] = count
+			intended[typo] = fix
+		}
+	}
+
+	// never corrected nearby, but one edit away from a known tool the user
+	// runs more often. two-letter commands are too likely to be personal
+	// aliases to guess without a correction
+	for cmd := range commandCounts {
+		if _, ok := intended[cmd]; ok || len(cmd) < 3 || !plausibleTypo(cmd) {
+			continue
+		}
+		if fix := closestKnownTool(cmd, commandCounts); fix != "" && commandCounts[cmd] < commandCounts[fix] {
+			intended[cmd] = fix
+		}
+	}
+
+	for typo, fix := range intended {
+		stats.TopTypos = append(stats.TopTypos, Typo{Typo: typo, Intended: fix, Count: commandCounts[typo]})
+		stats.TypoCount += commandCounts[typo]
+	}
+
+	sort.Slice(stats.TopTypos, func(i, j int) bool {
+		if stats.TopTypos[i].Count != stats.TopTypos[j].Count {
+			return stats.TopTypos[i].Count > stats.TopTypos[j].Count
+		}
+		return stats.TopTypos[i].Typo < stats.TopTypos[j].Typo
+	})
+
+	for _, typo := range stats.TopTypos {
+		if typo.Count >= typoAliasMin {
+			stats.TypoAliases = append(stats.TypoAliases, typo)
+		}
+	}
+	if len(stats.TopTypos) > topTypoCount {
+		stats.TopTypos = stats.TopTypos[:topTypoCount]
+	}
+	if stats.TotalCommands > 0 {
+		stats.TypoPct = float64(stats.TypoCount) / float64(stats.TotalCommands) * 100
+	}
+}
+
+// plausibleTypo filters out things that are clearly not mistyped tools:
+// known tools, paths, assignments and one-letter aliases
+func plausibleTypo(cmd string) bool {
+	if len(cmd) < 2 || knownTools[cmd] {
+		return false
+	}
+	return !strings.ContainsAny(cmd, "/.=~$")
+}
+
+// isTypoOf reports whether typo is within a small edit distance of target
+func isTypoOf(typo, target string) bool {
+	if len(target) < 2 || strings.ContainsAny(target, "/=$") {
+		return false
+	}
+	limit := 1
+	if len(target) >= 6 {
+		limit = 2
+	}
+	return editDistance(typo, target) <= limit
+}
+
+// closestKnownTool finds a known tool one edit away, preferring the most used one
+func closestKnownTool(cmd string, commandCounts map[string]int) string {
+	var best string
+	for tool := range knownTools {
+		if !isTypoOf(cmd, tool) {
+			continue
+		}
+		if best == "" || commandCounts[tool] > commandCounts[best] ||
+			(commandCounts[tool] == commandCounts[best] && tool < best) {
+			best = tool
+		}
+	}
+	return best
+}
+
+// editDistance is the optimal string alignment distance (Levenshtein plus
+// adjacent transpositions, so "gti" is one edit from "git")
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	rows := make([][]int, len(ra)+1)
+	for i := range rows {
+		rows[i] = make([]int, len(rb)+1)
+		rows[i][0] = i
+	}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			rows[i][j] = min(rows[i-1][j]+1, rows[i][j-1]+1, rows[i-1][j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				rows[i][j] = min(rows[i][j], rows[i-2][j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)][len(rb)]
+}
@@ -0,0 +1,95 @@
+package analyzer
+
+import (
+	"testing"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"git", "git", 0},
+		{"gti", "git", 1}, // transposition
+		{"gi", "git", 1},
+		{"gitt", "git", 1},
+		{"dokcer", "docker", 1},
+		{"sl", "ls", 1},
+		{"kubeclt", "kubectl", 1},
+		{"npm", "yarn", 4},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestPlausibleTypo(t *testing.T) {
+	tests := []struct {
+		cmd  string
+		want bool
+	}{
+		{"gti", true},
+		{"git", false},   // a known tool
+		{"g", false},     // one-letter aliases
+		{"./run", false}, // paths
+		{"FOO=1", false}, // assignments
+		{"~/bin/x", false},
+	}
+	for _, tt := range tests {
+		if got := plausibleTypo(tt.cmd); got != tt.want {
+			t.Errorf("plausibleTypo(%q) = %v, want %v", tt.cmd, got, tt.want)
+		}
+	}
+}
+
+func TestTypos(t *testing.T) {
+	stats := analyzeLines(t,
+		// retyped right after: a correction
+		"gti status", "git status",
+		"gti push", "git push",
+		"gti log", "git log",
+		// never corrected, but one edit from a tool used more often
+		"dokcer ps", "docker ps", "docker ps",
+		// a personal script nothing like a known tool
+		"deploy-prod", "deploy-prod",
+	)
+
+	want := map[string]string{"gti": "git", "dokcer": "docker"}
+	if len(stats.TopTypos) != len(want) {
+		t.Fatalf("TopTypos = %+v, want %v", stats.TopTypos, want)
+	}
+	for _, typo := range stats.TopTypos {
+		if want[typo.Typo] != typo.Intended {
+			t.Errorf("typo %q -> %q, want %q", typo.Typo, typo.Intended, want[typo.Typo])
+		}
+	}
+	if stats.TopTypos[0].Typo != "gti" || stats.TopTypos[0].Count != 3 {
+		t.Errorf("top typo = %+v, want gti x3", stats.TopTypos[0])
+	}
+	if stats.TypoCount != 4 {
+		t.Errorf("TypoCount = %d, want 4", stats.TypoCount)
+	}
+	// only typos made at least typoAliasMin times get an alias
+	if len(stats.TypoAliases) != 1 || stats.TypoAliases[0].Typo != "gti" {
+		t.Errorf("TypoAliases = %+v, want only gti", stats.TypoAliases)
+	}
+}
+
+func TestTypoCorrectionsStayInSession(t *testing.T) {
+	typos := newTypoTracker(newTopCounts[string](maxTrackedKeys))
+	typos.Add(&Event{Cmd: &parser.Command{Command: "mkae"}, Base: "mkae"})
+	typos.Add(&Event{Cmd: &parser.Command{Command: "make"}, Base: "make", NewSession: true})
+	if typos.corrections.distinct() != 0 {
+		t.Errorf("correction across a session boundary: %v", typos.corrections.snapshot(&Stats{}))
+	}
+
+	typos.Add(&Event{Cmd: &parser.Command{Command: "mkae"}, Base: "mkae"})
+	typos.Add(&Event{Cmd: &parser.Command{Command: "make"}, Base: "make"})
+	if got := typos.corrections.count([2]string{"mkae", "make"}); got != 1 {
+		t.Errorf("corrections[mkae make] = %d, want 1", got)
+	}
+}
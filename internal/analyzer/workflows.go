@@ -0,0 +1,189 @@
+package analyzer
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// workflow is a recurring sequence of consecutive commands
+type Workflow struct {
+	Steps []string
+	Count int
+}
+
+// transition is an edge of the command transition graph (From was followed by To)
+type Transition struct {
+	From  string
+	To    string
+	Count int
+}
+
+const (
+	maxWorkflowLen   = 3
+	minWorkflowCount = 3
+	topWorkflowCount = 5
+	topTransitions   = 40
+)
+
+// tools whose first argument is a subcommand worth keeping (git add vs git push)
+var subcommandTools = map[string]bool{
+	"git": true, "gh": true, "docker": true, "docker-compose": true, "podman": true,
+	"kubectl": true, "helm": true, "npm": true, "yarn": true, "pnpm": true,
+	"cargo": true, "go": true, "brew": true, "apt": true, "apt-get": true,
+	"systemctl": true, "terraform": true, "make": true, "pip": true, "pip3": true,
+}
+
+// commandKey names a command for sequence analysis: the base command plus
+// its subcommand for tools like git and docker
+func commandKey(cmd *parser.Command, baseCmd string) string {
+	if !subcommandTools[baseCmd] {
+		return baseCmd
+	}
+	args := cmd.Args
+	// drop anything before the base command (sudo, env vars, wrappers)
+	for i, arg := range args {
+		if arg == baseCmd {
+			args = args[i+1:]
+			break
+		}
+	}
+	for _, arg := range args {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		return baseCmd + " " + arg
+	}
+	return baseCmd
+}
+
+// workflowTracker mines n-grams of consecutive commands within a session
+type workflowTracker struct {
+	window      []string
+	ngrams      *topCounts[string]
+	transitions *topCounts[[2]string]
+}
+
+func newWorkflowTracker() *workflowTracker {
+	return &workflowTracker{
+		ngrams:      newTopCounts[string](maxTrackedKeys),
+		transitions: newTopCounts[[2]string](maxTrackedKeys),
+	}
+}
+
+func (w *workflowTracker) Add(e *Event) {
+	key, newSession := commandKey(e.Cmd, e.Base), e.NewSession
+	// sequences never cross a session boundary
+	if newSession {
+		w.window = w.window[:0]
+	}
+
+	// collapse immediate repeats (git status, git status, ...)
+	if len(w.window) > 0 && w.window[len(w.window)-1] == key {
+		return
+	}
+
+	w.window = append(w.window, key)
+	if len(w.window) > maxWorkflowLen {
+		w.window = w.window[1:]
+	}
+
+	n := len(w.window)
+	if n >= 2 {
+		w.transitions.add([2]string{w.window[n-2], w.window[n-1]})
+	}
+	for size := 2; size <= n; size++ {
+		w.ngrams.add(strings.Join(w.window[n-size:], "\x00"))
+	}
+}
+
+func (w *workflowTracker) Finish(stats *Stats) {
+	var candidates []Workflow
+	for gram, count := range w.ngrams.snapshot(stats) {
+		if count < minWorkflowCount {
+			continue
+		}
+		candidates = append(candidates, Workflow{Steps: strings.Split(gram, "\x00"), Count: count})
+	}
+
+	// longer sequences carry more signal, so weight by the number of hops
+	sort.Slice(candidates, func(i, j int) bool {
+		si := candidates[i].Count * (len(candidates[i].Steps) - 1)
+		sj := candidates[j].Count * (len(candidates[j].Steps) - 1)
+		if si != sj {
+			return si > sj
+		}
+		return strings.Join(candidates[i].Steps, " ") < strings.Join(candidates[j].Steps, " ")
+	})
+
+	for _, c := range candidates {
+		if len(stats.TopWorkflows) >= topWorkflowCount {
+			break
+		}
+		// skip pairs already covered by a longer workflow
+		covered := false
+		for _, top := range stats.TopWorkflows {
+			if len(top.Steps) > len(c.Steps) && containsSequence(top.Steps, c.Steps) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			stats.TopWorkflows = append(stats.TopWorkflows, c)
+		}
+	}
+
+	// signature combo: the strongest workflow made of distinct tools
+	for _, wf := range stats.TopWorkflows {
+		if distinctSteps(wf.Steps) {
+			stats.SignatureCombo = wf
+			break
+		}
+	}
+
+	for edge, count := range w.transitions.snapshot(stats) {
+		stats.Transitions = append(stats.Transitions, Transition{From: edge[0], To: edge[1], Count: count})
+	}
+	sort.Slice(stats.Transitions, func(i, j int) bool {
+		a, b := stats.Transitions[i], stats.Transitions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	if len(stats.Transitions) > topTransitions {
+		stats.Transitions = stats.Transitions[:topTransitions]
+	}
+}
+
+// containsSequence reports whether sub appears contiguously in seq
+func containsSequence(seq, sub []string) bool {
+	for i := 0; i+len(sub) <= len(seq); i++ {
+		match := true
+		for j := range sub {
+			if seq[i+j] != sub[j] {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+func distinctSteps(steps []string) bool {
+	seen := make(map[string]bool, len(steps))
+	for _, s := range steps {
+		if seen[s] {
+			return false
+		}
+		seen[s] = true
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package analyzer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+func TestCommandKey(t *testing.T) {
+	tests := []struct {
+		cmd  parser.Command
+		want string
+	}{
+		{parser.Command{Command: "ls", Args: []string{"-la"}}, "ls"},
+		{parser.Command{Command: "git", Args: []string{"push"}}, "git push"},
+		{parser.Command{Command: "git", Args: []string{"--no-pager", "log"}}, "git log"},
+		{parser.Command{Command: "sudo", Args: []string{"docker", "ps"}}, "docker ps"},
+		{parser.Command{Command: "git"}, "git"},
+	}
+	for _, tt := range tests {
+		cmd := tt.cmd
+		if got := commandKey(&cmd, parser.GetBaseCommand(&cmd)); got != tt.want {
+			t.Errorf("commandKey(%s %v) = %q, want %q", cmd.Command, cmd.Args, got, tt.want)
+		}
+	}
+}
+
+func TestWorkflows(t *testing.T) {
+	var lines []string
+	for day := 1; day <= 3; day++ {
+		lines = append(lines,
+			timed(at(day, 10, 0), "git add ."),
+			timed(at(day, 10, 1), "git commit -m wip"),
+			timed(at(day, 10, 2), "git push"),
+			// repeats collapse, so this doesn't break the sequence
+			timed(at(day, 10, 3), "git push"),
+		)
+	}
+	stats := analyzeLines(t, lines...)
+
+	if len(stats.TopWorkflows) == 0 {
+		t.Fatal("no workflows found")
+	}
+	want := Workflow{Steps: []string{"git add", "git commit", "git push"}, Count: 3}
+	if !reflect.DeepEqual(stats.TopWorkflows[0], want) {
+		t.Errorf("top workflow = %+v, want %+v", stats.TopWorkflows[0], want)
+	}
+	// the pairs inside it are covered by the longer workflow
+	for _, wf := range stats.TopWorkflows[1:] {
+		if len(wf.Steps) == 2 && containsSequence(want.Steps, wf.Steps) {
+			t.Errorf("pair %v reported next to the workflow containing it", wf.Steps)
+		}
+	}
+	if !reflect.DeepEqual(stats.SignatureCombo, want) {
+		t.Errorf("signature combo = %+v, want %+v", stats.SignatureCombo, want)
+	}
+
+	// git push -> git add is a session boundary, never a transition
+	for _, tr := range stats.Transitions {
+		if tr.From == "git push" {
+			t.Errorf("transition across sessions: %+v", tr)
+		}
+	}
+	if first := stats.Transitions[0]; first.Count != 3 {
+		t.Errorf("top transition = %+v, want count 3", first)
+	}
+}
+
+func TestWorkflowsNeedRepetition(t *testing.T) {
+	stats := analyzeLines(t,
+		timed(at(1, 10, 0), "make"),
+		timed(at(1, 10, 1), "./run"),
+		timed(at(2, 10, 0), "make"),
+		timed(at(2, 10, 1), "./run"),
+	)
+	if len(stats.TopWorkflows) != 0 {
+		t.Errorf("workflows seen only twice reported: %+v", stats.TopWorkflows)
+	}
+}
+
+func TestContainsSequence(t *testing.T) {
+	seq := []string{"a", "b", "c"}
+	tests := []struct {
+		sub  []string
+		want bool
+	}{
+		{[]string{"a", "b"}, true},
+		{[]string{"b", "c"}, true},
+		{[]string{"a", "c"}, false},
+		{[]string{"c", "d"}, false},
+	}
+	for _, tt := range tests {
+		if got := containsSequence(seq, tt.sub); got != tt.want {
+			t.Errorf("containsSequence(%v, %v) = %v, want %v", seq, tt.sub, got, tt.want)
+		}
+	}
+}
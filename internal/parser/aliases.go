@@ -0,0 +1,269 @@
+package parser
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// aliases maps alias names to their expansion
+type Aliases map[string]string
+
+// maximum nesting when an alias expands to another alias
+const maxAliasDepth = 10
+
+// oh-my-zsh plugin list: plugins=(git kubectl docker), possibly spanning lines
+var omzPluginsRegex = regexp.MustCompile(`(?s)(?:^|\n)\s*plugins=\(([^)]*)\)`)
+
+// function definitions: name() {, function name { and fish's function name
+var functionRegex = regexp.MustCompile(`(?m)^\s*(?:function\s+([A-Za-z_][\w.:-]*)|([A-Za-z_][\w.:-]*)\s*\(\s*\))`)
+
+// loadAliases collects alias definitions from the usual zsh, bash and fish
+// config files plus enabled oh-my-zsh plugins. files are only read, never
+// executed, so aliases defined dynamically are not picked up
+func LoadAliases(home string) Aliases {
+	aliases := make(Aliases)
+	for _, file := range configFiles(home) {
+		aliases.loadFile(file)
+	}
+	return aliases
+}
+
+// loadFunctions collects the names of shell functions defined in the same
+// config files as the aliases
+func LoadFunctions(home string) map[string]bool {
+	functions := make(map[string]bool)
+	for _, file := range configFiles(home) {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for _, m := range functionRegex.FindAllStringSubmatch(string(content), -1) {
+			functions[m[1]+m[2]] = true
+		}
+	}
+	return functions
+}
+
+// configFiles lists the shell config files to read, oh-my-zsh ones first so
+// the user's own definitions win
+func configFiles(home string) []string {
+	files := []string{
+		filepath.Join(home, ".bashrc"),
+		filepath.Join(home, ".bash_profile"),
+		filepath.Join(home, ".bash_aliases"),
+		filepath.Join(home, ".aliases"),
+		filepath.Join(home, ".config", "fish", "config.fish"),
+	}
+	if conf, _ := filepath.Glob(filepath.Join(home, ".config", "fish", "conf.d", "*.fish")); conf != nil {
+		files = append(files, conf...)
+	}
+
+	zshrc := filepath.Join(home, ".zshrc")
+	files = append(omzAliasFiles(home, zshrc), files...)
+	return append(files, zshrc, filepath.Join(home, ".zsh_aliases"))
+}
+
+// omzAliasFiles lists the oh-my-zsh library and enabled plugin files
+func omzAliasFiles(home, zshrc string) []string {
+	content, err := os.ReadFile(zshrc)
+	if err != nil {
+		return nil
+	}
+
+	zshDir := os.Getenv("ZSH")
+	if zshDir == "" {
+		zshDir = filepath.Join(home, ".oh-my-zsh")
+	}
+	customDir := os.Getenv("ZSH_CUSTOM")
+	if customDir == "" {
+		customDir = filepath.Join(zshDir, "custom")
+	}
+
+	files, _ := filepath.Glob(filepath.Join(zshDir, "lib", "*.zsh"))
+
+	matches := omzPluginsRegex.FindAllStringSubmatch(string(content), -1)
+	for _, m := range matches {
+		for _, plugin := range strings.Fields(m[1]) {
+			name := plugin + ".plugin.zsh"
+			// custom plugins override bundled ones of the same name
+			for _, dir := range []string{filepath.Join(zshDir, "plugins"), filepath.Join(customDir, "plugins")} {
+				files = append(files, filepath.Join(dir, plugin, name))
+			}
+		}
+	}
+
+	custom, _ := filepath.Glob(filepath.Join(customDir, "*.zsh"))
+	return append(files, custom...)
+}
+
+// loadFile adds every alias/abbr definition found in a file
+func (a Aliases) loadFile(path string) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		for name, value := range parseAliasLine(scanner.Text()) {
+			a[name] = value
+		}
+	}
+}
+
+// parseAliasLine extracts definitions from one config line. it understands
+// alias name='value' (zsh/bash, several per line), alias name 'value' (fish)
+// and abbr -a name value (fish)
+func parseAliasLine(line string) map[string]string {
+	words := shellWords(line)
+	if len(words) < 2 {
+		return nil
+	}
+
+	keyword := words[0]
+	if keyword != "alias" && keyword != "abbr" {
+		return nil
+	}
+
+	// skip options; zsh suffix aliases (-s) map file extensions, not commands
+	rest := words[1:]
+	for len(rest) > 0 && strings.HasPrefix(rest[0], "-") {
+		if keyword == "alias" && isSuffixAliasFlag(rest[0]) {
+			return nil
+		}
+		rest = rest[1:]
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+
+	defs := make(map[string]string)
+
+	if keyword == "abbr" {
+		if len(rest) >= 2 {
+			defs[rest[0]] = strings.Join(rest[1:], " ")
+		}
+		return defs
+	}
+
+	for i := 0; i < len(rest); i++ {
+		name, value, found := strings.Cut(rest[i], "=")
+		if !found {
+			// fish style: alias name value
+			if i+1 < len(rest) {
+				defs[rest[i]] = rest[i+1]
+			}
+			break
+		}
+		if name != "" && value != "" {
+			defs[name] = value
+		}
+	}
+	return defs
+}
+
+// isSuffixAliasFlag matches zsh's -s, alone or in a cluster like -gs. long
+// options such as fish's --save don't count
+func isSuffixAliasFlag(option string) bool {
+	return !strings.HasPrefix(option, "--") && strings.Contains(option[1:], "s")
+}
+
+// shellWords splits a line into words the way a shell would for simple
+// cases: quotes are removed, backslash escapes honored, # starts a comment
+func shellWords(line string) []string {
+	var words []string
+	var current strings.Builder
+	inWord := false
+	quote := rune(0)
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else if r == '\\' && quote == '"' {
+				escaped = true
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '#' && !inWord:
+			return words
+		case r == ' ' || r == '\t' || r == ';':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if inWord {
+		words = append(words, current.String())
+	}
+	return words
+}
+
+// expand rewrites a command typed as an alias into the real command,
+// following nested aliases. cmd.Alias keeps the name the user typed, except
+// for aliases like ls='ls -G' that only add options to the command itself.
+// it reports whether the command was an alias
+func (a Aliases) Expand(cmd *Command) bool {
+	name := cmd.Command
+	value, ok := a[name]
+	if !ok {
+		return false
+	}
+
+	seen := map[string]bool{name: true}
+	parts := parseCommandParts(value)
+	for depth := 0; depth < maxAliasDepth && len(parts) > 0; depth++ {
+		next, ok := a[parts[0]]
+		// alias ls='ls -G' refers to the real ls
+		if !ok || seen[parts[0]] {
+			break
+		}
+		seen[parts[0]] = true
+		parts = append(parseCommandParts(next), parts[1:]...)
+	}
+	if len(parts) == 0 {
+		return false
+	}
+
+	if parts[0] != name {
+		cmd.Alias = name
+	}
+	cmd.Command = parts[0]
+	cmd.Args = append(parts[1:], cmd.Args...)
+	return true
+}
+
+// expandAliases resolves aliases in every command and returns how many were expanded
+func (d *HistoryData) ExpandAliases(aliases Aliases) int {
+	if len(aliases) == 0 {
+		return 0
+	}
+	expanded := 0
+	for i := range d.Commands {
+		if aliases.Expand(&d.Commands[i]) {
+			expanded++
+		}
+	}
+	return expanded
+}
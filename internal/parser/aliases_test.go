@@ -0,0 +1,113 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseAliasLine(t *testing.T) {
+	tests := []struct {
+		line string
+		want map[string]string
+	}{
+		{"alias gs='git status'", map[string]string{"gs": "git status"}},
+		{`alias ll="ls -la" la='ls -A'`, map[string]string{"ll": "ls -la", "la": "ls -A"}},
+		{"alias -g G='| grep'", map[string]string{"G": "| grep"}},
+		{"alias k=kubectl # short", map[string]string{"k": "kubectl"}},
+		// fish
+		{"alias gco 'git checkout'", map[string]string{"gco": "git checkout"}},
+		{"alias --save gp 'git push'", map[string]string{"gp": "git push"}},
+		{"abbr -a gd git diff", map[string]string{"gd": "git diff"}},
+		// zsh suffix aliases map extensions, not commands
+		{"alias -s py=python3", nil},
+		{"alias -gs md=glow", nil},
+		{"# alias x=y", nil},
+		{"export PATH=$PATH:~/bin", nil},
+	}
+	for _, tt := range tests {
+		got := parseAliasLine(tt.line)
+		if len(got) == 0 && len(tt.want) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseAliasLine(%q) = %v, want %v", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	aliases := Aliases{
+		"g":   "git",
+		"gst": "g status",
+		"ls":  "ls -G",
+		"ll":  "ls -l",
+		"a":   "b",
+		"b":   "a",
+	}
+	tests := []struct {
+		typed    Command
+		want     Command
+		expanded bool
+	}{
+		{Command{Command: "g", Args: []string{"push"}}, Command{Command: "git", Args: []string{"push"}, Alias: "g"}, true},
+		// nested
+		{Command{Command: "gst"}, Command{Command: "git", Args: []string{"status"}, Alias: "gst"}, true},
+		// an alias for the command itself only adds options
+		{Command{Command: "ls", Args: []string{"src"}}, Command{Command: "ls", Args: []string{"-G", "src"}}, true},
+		{Command{Command: "ll"}, Command{Command: "ls", Args: []string{"-G", "-l"}, Alias: "ll"}, true},
+		// cycles stop
+		{Command{Command: "a"}, Command{Command: "a", Alias: ""}, true},
+		{Command{Command: "git", Args: []string{"log"}}, Command{Command: "git", Args: []string{"log"}}, false},
+	}
+	for _, tt := range tests {
+		cmd := tt.typed
+		expanded := aliases.Expand(&cmd)
+		if expanded != tt.expanded {
+			t.Errorf("Expand(%s) = %v, want %v", tt.typed.Command, expanded, tt.expanded)
+		}
+		if len(cmd.Args) == 0 {
+			cmd.Args = nil
+		}
+		if !reflect.DeepEqual(cmd, tt.want) {
+			t.Errorf("Expand(%s) gave %+v, want %+v", tt.typed.Command, cmd, tt.want)
+		}
+	}
+}
+
+func TestLoadAliases(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("ZSH", filepath.Join(home, ".oh-my-zsh"))
+	t.Setenv("ZSH_CUSTOM", "")
+	write := func(name, content string) {
+		path := filepath.Join(home, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(".oh-my-zsh/plugins/git/git.plugin.zsh", "alias gst='git status'\nalias gp='git push'\n")
+	write(".zshrc", "plugins=(\n  git\n)\nalias gp='git push --force-with-lease'\n")
+	write(".bashrc", "alias ..='cd ..'\nmkcd() {\n  mkdir -p \"$1\" && cd \"$1\"\n}\n")
+	write(".config/fish/config.fish", "abbr -a k kubectl\nfunction gco\n    git checkout $argv\nend\n")
+	write(".zsh_aliases", "function serve {\n  python3 -m http.server\n}\n  up () { cd ..; }\n")
+
+	want := Aliases{
+		"gst": "git status",
+		// the user's own definition beats the plugin
+		"gp": "git push --force-with-lease",
+		"..": "cd ..",
+		"k":  "kubectl",
+	}
+	if got := LoadAliases(home); !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAliases = %v, want %v", got, want)
+	}
+
+	functions := map[string]bool{"mkcd": true, "gco": true, "serve": true, "up": true}
+	if got := LoadFunctions(home); !reflect.DeepEqual(got, functions) {
+		t.Errorf("LoadFunctions = %v, want %v", got, functions)
+	}
+}
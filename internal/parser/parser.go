@@ -1,7 +1,6 @@
 package parser
 
 import (
-	"bufio"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -17,6 +16,7 @@ type Command struct {
 	Args      []string  // arguments
 	Timestamp time.Time // zero value if no timestamp available
 	HasTime   bool      // whether we have timestamp data
+	Alias     string    // alias the user typed, if Command was expanded from one
 }
 
 // historyData contains all parsed history information
@@ -24,7 +24,8 @@ type HistoryData struct {
 	Commands  []Command
 	Shell     string // "zsh" or "bash"
 	FilePath  string
-	HasTimes  bool // whether timestamps are available
+	HasTimes  bool           // whether timestamps are available
+	Location  *time.Location // zone timestamps are shown in, nil for the local zone
 	ParsedAt  time.Time
 	LineCount int
 }
@@ -67,7 +68,8 @@ func GetHistoryPath(shell string) string {
 	}
 }
 
-// parse reads and parses a shell history file
+// parse reads and parses a shell history file into memory. use NewScanner
+// to stream a history instead
 func Parse(historyPath string, shell string) (*HistoryData, error) {
 	file, err := os.Open(historyPath)
 	if err != nil {
@@ -76,64 +78,17 @@ func Parse(historyPath string, shell string) (*HistoryData, error) {
 	defer file.Close()
 
 	data := &HistoryData{
-		Commands: make([]Command, 0, 10000),
 		Shell:    shell,
 		FilePath: historyPath,
 		ParsedAt: time.Now(),
 	}
 
-	scanner := bufio.NewScanner(file)
-	// increase buffer size for very long commands
-	buf := make([]byte, 0, 64*1024)
-	scanner.Buffer(buf, 1024*1024)
-
-	var multilineCmd strings.Builder
-	inMultiline := false
-
+	scanner := NewScanner(file, shell)
 	for scanner.Scan() {
-		line := scanner.Text()
-		data.LineCount++
-
-		// handle multiline commands (ending with \)
-		if inMultiline {
-			multilineCmd.WriteString("\n")
-			multilineCmd.WriteString(line)
-			if !strings.HasSuffix(line, "\\") {
-				inMultiline = false
-				cmd := parseCommand(multilineCmd.String(), shell)
-				if cmd != nil {
-					if cmd.HasTime {
-						data.HasTimes = true
-					}
-					data.Commands = append(data.Commands, *cmd)
-				}
-				multilineCmd.Reset()
-			}
-			continue
-		}
-
-		if strings.HasSuffix(line, "\\") {
-			inMultiline = true
-			multilineCmd.WriteString(line)
-			continue
-		}
-
-		cmd := parseCommand(line, shell)
-		if cmd != nil {
-			if cmd.HasTime {
-				data.HasTimes = true
-			}
-			data.Commands = append(data.Commands, *cmd)
-		}
-	}
-
-	// handle any remaining multiline command
-	if multilineCmd.Len() > 0 {
-		cmd := parseCommand(multilineCmd.String(), shell)
-		if cmd != nil {
-			data.Commands = append(data.Commands, *cmd)
-		}
+		data.Commands = append(data.Commands, *scanner.Command())
 	}
+	data.LineCount = scanner.LineCount()
+	data.HasTimes = scanner.HasTimes()
 
 	return data, scanner.Err()
 }
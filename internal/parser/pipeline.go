@@ -0,0 +1,101 @@
+package parser
+
+import "strings"
+
+// splitPipelines splits a command line into its pipelines, each given as
+// the trimmed text of its stages. pipelines are separated by ;, &&, || and &,
+// stages by | and |&. quotes, backslash escapes, backticks and anything
+// nested in (), $() or {} are never split, so grep 'a|b' is one stage
+func SplitPipelines(line string) [][]string {
+	// most lines are a single command, with nothing to split on
+	if !strings.ContainsAny(line, "|&;\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			return nil
+		}
+		return [][]string{{line}}
+	}
+
+	var pipelines [][]string
+	var stages []string
+	var current strings.Builder
+
+	endStage := func() {
+		stages = append(stages, strings.TrimSpace(current.String()))
+		current.Reset()
+	}
+	endPipeline := func() {
+		endStage()
+		// drop empty pipelines (a trailing ; or &)
+		if len(stages) > 1 || stages[0] != "" {
+			pipelines = append(pipelines, stages)
+		}
+		stages = nil
+	}
+
+	var quote rune
+	depth := 0
+	runes := []rune(line)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		next := rune(0)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		prev := rune(0)
+		if i > 0 {
+			prev = runes[i-1]
+		}
+
+		switch {
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			}
+		case r == '\\' && next != 0:
+			current.WriteRune(r)
+			i++
+			r = next
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"' || r == '`':
+			quote = r
+		case r == '(' || r == '{':
+			depth++
+		case (r == ')' || r == '}') && depth > 0:
+			depth--
+		case depth > 0:
+		case r == '|' && next == '|':
+			endPipeline()
+			i++
+			continue
+		case r == '|' && prev != '>':
+			endStage()
+			// |& pipes stderr too
+			if next == '&' {
+				i++
+			}
+			continue
+		case r == '&' && next == '&':
+			endPipeline()
+			i++
+			continue
+		case r == '&' && prev != '>' && next != '>':
+			endPipeline()
+			continue
+		case r == ';' || r == '\n':
+			endPipeline()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	endPipeline()
+
+	return pipelines
+}
+
+// parseStage parses one pipeline stage like a history line
+func ParseStage(stage string) *Command {
+	return parseCommand(stage, "")
+}
@@ -0,0 +1,48 @@
+package parser
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitPipelines(t *testing.T) {
+	tests := []struct {
+		line string
+		want [][]string
+	}{
+		{"ls", [][]string{{"ls"}}},
+		{"  ls -la ", [][]string{{"ls -la"}}},
+		{"cat a | grep x | wc -l", [][]string{{"cat a", "grep x", "wc -l"}}},
+		{"make && ./run || echo fail", [][]string{{"make"}, {"./run"}, {"echo fail"}}},
+		{"cd src; ls &", [][]string{{"cd src"}, {"ls"}}},
+		{"make |& tee log", [][]string{{"make", "tee log"}}},
+		{"grep 'a|b' f | sort", [][]string{{"grep 'a|b' f", "sort"}}},
+		{`echo "x; y" \| z`, [][]string{{`echo "x; y" \| z`}}},
+		{"echo $(ls | wc -l) | cat", [][]string{{"echo $(ls | wc -l)", "cat"}}},
+		{"(cd /tmp && make) | tee log", [][]string{{"(cd /tmp && make)", "tee log"}}},
+		{"{ a; b; } > out", [][]string{{"{ a; b; } > out"}}},
+		{"cmd >| file", [][]string{{"cmd >| file"}}},
+		{"cmd >&2 && cmd &> log", [][]string{{"cmd >&2"}, {"cmd &> log"}}},
+		{"echo `a | b`", [][]string{{"echo `a | b`"}}},
+		{"", nil},
+		{";", nil},
+	}
+	for _, tt := range tests {
+		if got := SplitPipelines(tt.line); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("SplitPipelines(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestParseStage(t *testing.T) {
+	cmd := ParseStage("sudo grep -rn TODO src")
+	if cmd == nil {
+		t.Fatal("ParseStage returned nil")
+	}
+	if base := GetBaseCommand(cmd); base != "grep" {
+		t.Errorf("base command = %q, want grep", base)
+	}
+	if cmd.Raw != "sudo grep -rn TODO src" || cmd.HasTime {
+		t.Errorf("ParseStage = %+v", cmd)
+	}
+}
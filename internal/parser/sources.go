@@ -0,0 +1,127 @@
+package parser
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// source is one history file and the timezone its commands were typed in
+type Source struct {
+	Path     string
+	Shell    string
+	Location *time.Location // nil keeps the default zone
+}
+
+// parseSource splits a "path[@Zone]" argument such as
+// ~/server_history@UTC or ~/.zsh_history@Europe/Berlin
+func ParseSource(arg, defaultShell string) (Source, error) {
+	src := Source{Path: arg}
+
+	// a file whose name really contains @ wins over a zone suffix
+	if _, err := os.Stat(arg); err != nil {
+		if i := strings.LastIndex(arg, "@"); i > 0 {
+			loc, err := time.LoadLocation(arg[i+1:])
+			if err != nil {
+				return src, fmt.Errorf("unknown timezone %q for %s", arg[i+1:], arg[:i])
+			}
+			src.Path, src.Location = arg[:i], loc
+		}
+	}
+
+	src.Shell = shellForFile(src.Path, defaultShell)
+	return src, nil
+}
+
+// shellForFile guesses the history format from the file name, then from
+// the first line (zsh extended history starts with ": <epoch>:")
+func shellForFile(path, defaultShell string) string {
+	name := strings.ToLower(filepath.Base(path))
+	switch {
+	case strings.Contains(name, "zsh"):
+		return "zsh"
+	case strings.Contains(name, "bash"):
+		return "bash"
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return defaultShell
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	if scanner.Scan() && zshExtendedRegex.MatchString(scanner.Text()) {
+		return "zsh"
+	}
+	return defaultShell
+}
+
+// setLocation moves every timestamp into loc, so hours and days are
+// bucketed in the zone the commands were actually typed in
+func (d *HistoryData) SetLocation(loc *time.Location) {
+	if loc == nil {
+		return
+	}
+	d.Location = loc
+	d.ParsedAt = d.ParsedAt.In(loc)
+	for i := range d.Commands {
+		if d.Commands[i].HasTime {
+			d.Commands[i].Timestamp = d.Commands[i].Timestamp.In(loc)
+		}
+	}
+}
+
+// merge combines several histories into one, interleaving commands
+// chronologically. each source is assumed to be in file order already
+func Merge(sources ...*HistoryData) *HistoryData {
+	if len(sources) == 1 {
+		return sources[0]
+	}
+
+	merged := &HistoryData{}
+	var paths, shells []string
+	for _, src := range sources {
+		merged.LineCount += src.LineCount
+		merged.HasTimes = merged.HasTimes || src.HasTimes
+		paths = append(paths, src.FilePath)
+		shells = append(shells, src.Shell)
+		if src.ParsedAt.After(merged.ParsedAt) {
+			merged.ParsedAt = src.ParsedAt
+		}
+		if merged.Location == nil {
+			merged.Location = src.Location
+		}
+	}
+	merged.FilePath = strings.Join(paths, ", ")
+	merged.Shell = strings.Join(uniqueStrings(shells), "+")
+
+	// interleave chronologically (see MergeStreams)
+	streams := make([]CommandStream, len(sources))
+	total := 0
+	for i, src := range sources {
+		streams[i] = src.Stream()
+		total += len(src.Commands)
+	}
+	merged.Commands = make([]Command, 0, total)
+	stream := MergeStreams(streams...)
+	for stream.Scan() {
+		merged.Commands = append(merged.Commands, *stream.Command())
+	}
+	return merged
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
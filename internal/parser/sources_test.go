@@ -0,0 +1,204 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone data for %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseSource(t *testing.T) {
+	dir := t.TempDir()
+	// a file whose name really contains @
+	odd := filepath.Join(dir, "history@UTC")
+	if err := os.WriteFile(odd, []byte("ls\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	berlin := mustLoad(t, "Europe/Berlin")
+
+	tests := []struct {
+		arg      string
+		path     string
+		location *time.Location
+	}{
+		{"/srv/zsh_history@UTC", "/srv/zsh_history", time.UTC},
+		{"~/server_history@Europe/Berlin", "~/server_history", berlin},
+		{"/srv/bash_history", "/srv/bash_history", nil},
+		{odd, odd, nil},
+	}
+	for _, tt := range tests {
+		src, err := ParseSource(tt.arg, "zsh")
+		if err != nil {
+			t.Errorf("ParseSource(%q): %v", tt.arg, err)
+			continue
+		}
+		if src.Path != tt.path {
+			t.Errorf("ParseSource(%q).Path = %q, want %q", tt.arg, src.Path, tt.path)
+		}
+		if src.Location.String() != tt.location.String() || (src.Location == nil) != (tt.location == nil) {
+			t.Errorf("ParseSource(%q).Location = %v, want %v", tt.arg, src.Location, tt.location)
+		}
+	}
+
+	if _, err := ParseSource("/srv/history@Mars/Olympus", "zsh"); err == nil {
+		t.Error("ParseSource accepted an unknown time zone")
+	} else if !strings.Contains(err.Error(), "Mars/Olympus") {
+		t.Errorf("error %q doesn't name the zone", err)
+	}
+}
+
+func TestShellForFile(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{write("old_zsh_history", "ls\n"), "zsh"},
+		{write("server.bash_history", ": 1700000000:0;ls\n"), "bash"},
+		// named neither way: zsh extended history is recognized by content
+		{write("laptop", ": 1700000000:0;ls\n"), "zsh"},
+		{write("plain", "ls\n"), "fish"},
+		{filepath.Join(dir, "missing"), "fish"},
+	}
+	for _, tt := range tests {
+		if got := shellForFile(tt.path, "fish"); got != tt.want {
+			t.Errorf("shellForFile(%s) = %q, want %q", filepath.Base(tt.path), got, tt.want)
+		}
+	}
+}
+
+// zshHistory builds zsh extended history from instants and commands
+func zshHistory(entries ...any) string {
+	var b strings.Builder
+	for i := 0; i < len(entries); i += 2 {
+		fmt.Fprintf(&b, ": %d:0;%s\n", entries[i].(time.Time).Unix(), entries[i+1])
+	}
+	return b.String()
+}
+
+func TestMergeAcrossZones(t *testing.T) {
+	tokyo := mustLoad(t, "Asia/Tokyo")
+	newYork := mustLoad(t, "America/New_York")
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	laptop := NewScanner(strings.NewReader(zshHistory(
+		base, "first",
+		base.Add(2*time.Hour), "third",
+	)), "zsh")
+	laptop.SetLocation(newYork)
+	server := NewScanner(strings.NewReader(zshHistory(
+		base.Add(time.Hour), "second",
+		base.Add(3*time.Hour), "fourth",
+	)), "zsh")
+	server.SetLocation(tokyo)
+
+	var order []string
+	var zones []string
+	stream := MergeStreams(laptop, server)
+	for stream.Scan() {
+		cmd := stream.Command()
+		order = append(order, cmd.Command)
+		zones = append(zones, cmd.Timestamp.Location().String())
+	}
+	if err := stream.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	// wall clock times differ by 13 hours, instants decide the order
+	if got := strings.Join(order, " "); got != "first second third fourth" {
+		t.Errorf("merged order = %s", got)
+	}
+	// each command keeps the zone it was typed in
+	if got := strings.Join(zones, " "); got != "America/New_York Asia/Tokyo America/New_York Asia/Tokyo" {
+		t.Errorf("zones = %s", got)
+	}
+}
+
+func TestMergeUntimedCommands(t *testing.T) {
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	timedHistory := &HistoryData{Commands: []Command{
+		{Command: "a", Timestamp: base, HasTime: true},
+		{Command: "c", Timestamp: base.Add(2 * time.Hour), HasTime: true},
+	}, HasTimes: true, LineCount: 2, Shell: "zsh", FilePath: "one"}
+	plain := &HistoryData{Commands: []Command{
+		{Command: "x"},
+		{Command: "y"},
+	}, LineCount: 2, Shell: "bash", FilePath: "two"}
+
+	merged := Merge(timedHistory, plain)
+	var got []string
+	for _, cmd := range merged.Commands {
+		got = append(got, cmd.Command)
+	}
+	// untimed commands can't be placed in time, so they go as soon as they
+	// reach the head of their stream
+	if strings.Join(got, " ") != "x y a c" {
+		t.Errorf("merged order = %v, want x y a c", got)
+	}
+	if merged.LineCount != 4 || !merged.HasTimes || merged.Shell != "zsh+bash" || merged.FilePath != "one, two" {
+		t.Errorf("merged metadata = %+v", merged)
+	}
+}
+
+func TestSetLocationAcrossDST(t *testing.T) {
+	newYork := mustLoad(t, "America/New_York")
+	tests := []struct {
+		name    string
+		instant time.Time
+		day     int
+		hour    int
+	}{
+		// spring forward, 2025-03-09: 02:00 EST jumps to 03:00 EDT
+		{"before spring forward", time.Date(2025, 3, 9, 6, 30, 0, 0, time.UTC), 9, 1},
+		{"after spring forward", time.Date(2025, 3, 9, 7, 30, 0, 0, time.UTC), 9, 3},
+		// fall back, 2025-11-02: 02:00 EDT falls back to 01:00 EST, so
+		// two instants an hour apart share the 01:00 bucket
+		{"first 01:30", time.Date(2025, 11, 2, 5, 30, 0, 0, time.UTC), 2, 1},
+		{"second 01:30", time.Date(2025, 11, 2, 6, 30, 0, 0, time.UTC), 2, 1},
+		// late evening in New York is already the next day in UTC
+		{"day boundary", time.Date(2025, 11, 3, 4, 30, 0, 0, time.UTC), 2, 23},
+	}
+
+	var lines []any
+	for _, tt := range tests {
+		lines = append(lines, tt.instant, tt.name)
+	}
+	data := &HistoryData{ParsedAt: time.Now()}
+	scanner := NewScanner(strings.NewReader(zshHistory(lines...)), "zsh")
+	for scanner.Scan() {
+		data.Commands = append(data.Commands, *scanner.Command())
+	}
+	data.SetLocation(newYork)
+
+	if data.Location != newYork || data.ParsedAt.Location() != newYork {
+		t.Errorf("SetLocation didn't move the history into %v", newYork)
+	}
+	for i, tt := range tests {
+		ts := data.Commands[i].Timestamp
+		if !ts.Equal(tt.instant) {
+			t.Errorf("%s: instant changed from %v to %v", tt.name, tt.instant, ts)
+		}
+		if ts.Day() != tt.day || ts.Hour() != tt.hour {
+			t.Errorf("%s: bucketed on day %d hour %d, want day %d hour %d", tt.name, ts.Day(), ts.Hour(), tt.day, tt.hour)
+		}
+	}
+}
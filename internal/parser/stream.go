@@ -0,0 +1,198 @@
+package parser
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"time"
+)
+
+// commandStream yields commands one at a time. the command returned by
+// Command stays valid until the next call to Scan
+type CommandStream interface {
+	Scan() bool
+	Command() *Command
+	Err() error
+}
+
+// maxLineLength is the longest history line the scanner accepts
+const maxLineLength = 1024 * 1024
+
+// scanner reads shell history from a reader one command at a time, so a
+// history never has to fit in memory
+type Scanner struct {
+	lines    *bufio.Scanner
+	shell    string
+	location *time.Location
+
+	cmd       *Command
+	lineCount int
+	hasTimes  bool
+}
+
+// newScanner reads history in the given shell's format from r
+func NewScanner(r io.Reader, shell string) *Scanner {
+	lines := bufio.NewScanner(r)
+	// increase buffer size for very long commands
+	lines.Buffer(make([]byte, 0, 64*1024), maxLineLength)
+	return &Scanner{lines: lines, shell: shell}
+}
+
+// setLocation moves the timestamps of every following command into loc
+func (s *Scanner) SetLocation(loc *time.Location) {
+	s.location = loc
+}
+
+// scan advances to the next command, returning false at the end of the
+// input or on a read error
+func (s *Scanner) Scan() bool {
+	var multiline strings.Builder
+	for s.lines.Scan() {
+		line := s.lines.Text()
+		s.lineCount++
+
+		// handle multiline commands (ending with \)
+		if strings.HasSuffix(line, "\\") {
+			if multiline.Len() > 0 {
+				multiline.WriteString("\n")
+			}
+			multiline.WriteString(line)
+			continue
+		}
+		if multiline.Len() > 0 {
+			multiline.WriteString("\n")
+			multiline.WriteString(line)
+			line = multiline.String()
+			multiline.Reset()
+		}
+
+		if s.set(line) {
+			return true
+		}
+	}
+
+	// handle any remaining multiline command
+	if multiline.Len() > 0 && s.set(multiline.String()) {
+		return true
+	}
+	s.cmd = nil
+	return false
+}
+
+// set parses line into the current command, reporting whether it held one
+func (s *Scanner) set(line string) bool {
+	cmd := parseCommand(line, s.shell)
+	if cmd == nil {
+		return false
+	}
+	if cmd.HasTime {
+		s.hasTimes = true
+		if s.location != nil {
+			cmd.Timestamp = cmd.Timestamp.In(s.location)
+		}
+	}
+	s.cmd = cmd
+	return true
+}
+
+// command returns the most recent command read by Scan
+func (s *Scanner) Command() *Command { return s.cmd }
+
+// err returns the first read error, if any
+func (s *Scanner) Err() error { return s.lines.Err() }
+
+// lineCount returns how many lines have been read so far
+func (s *Scanner) LineCount() int { return s.lineCount }
+
+// hasTimes reports whether any command read so far had a timestamp
+func (s *Scanner) HasTimes() bool { return s.hasTimes }
+
+// sliceStream streams commands already in memory
+type sliceStream struct {
+	commands []Command
+	next     int
+}
+
+// stream returns the parsed commands as a CommandStream
+func (d *HistoryData) Stream() CommandStream {
+	return &sliceStream{commands: d.Commands}
+}
+
+func (s *sliceStream) Scan() bool {
+	if s.next >= len(s.commands) {
+		return false
+	}
+	s.next++
+	return true
+}
+
+func (s *sliceStream) Command() *Command { return &s.commands[s.next-1] }
+
+func (s *sliceStream) Err() error { return nil }
+
+// mergedStream interleaves several streams chronologically
+type mergedStream struct {
+	streams []CommandStream
+	heads   []*Command // next command of each stream, nil once it ran out
+	started bool
+	current int
+	err     error
+}
+
+// mergeStreams combines several streams into one, repeatedly taking the
+// earliest head command. commands without a timestamp are taken as soon as
+// they reach the head, so they stay next to the commands around them.
+// instants compare correctly even when streams are in different zones.
+// each stream is assumed to be in file order already
+func MergeStreams(streams ...CommandStream) CommandStream {
+	if len(streams) == 1 {
+		return streams[0]
+	}
+	return &mergedStream{
+		streams: streams,
+		heads:   make([]*Command, len(streams)),
+		current: -1,
+	}
+}
+
+func (m *mergedStream) Scan() bool {
+	if !m.started {
+		m.started = true
+		for i := range m.streams {
+			m.advance(i)
+		}
+	} else if m.current >= 0 {
+		m.advance(m.current)
+	}
+
+	m.current = -1
+	for i, head := range m.heads {
+		if head == nil {
+			continue
+		}
+		if !head.HasTime {
+			m.current = i
+			break
+		}
+		if m.current < 0 || head.Timestamp.Before(m.heads[m.current].Timestamp) {
+			m.current = i
+		}
+	}
+	return m.current >= 0
+}
+
+// advance moves stream i to its next command
+func (m *mergedStream) advance(i int) {
+	m.heads[i] = nil
+	if m.streams[i].Scan() {
+		m.heads[i] = m.streams[i].Command()
+		return
+	}
+	if err := m.streams[i].Err(); err != nil && m.err == nil {
+		m.err = err
+	}
+}
+
+func (m *mergedStream) Command() *Command { return m.heads[m.current] }
+
+func (m *mergedStream) Err() error { return m.err }
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// renderAliasSnippet writes the suggested aliases as a ready-to-source snippet
+// for zsh, bash or fish
+func RenderAliasSnippet(stats *analyzer.Stats, shell string) (string, error) {
+	if shell != "zsh" && shell != "bash" && shell != "fish" {
+		return "", fmt.Errorf("unsupported shell %q (use zsh, bash or fish)", shell)
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# terminal-wrapped alias suggestions\n")
+	sb.WriteString(fmt.Sprintf("# source this file from your %s config, or copy the ones you like\n", shell))
+
+	if len(stats.AliasSuggestions) > 0 {
+		sb.WriteString("\n# repeated long commands\n")
+	}
+	for _, s := range stats.AliasSuggestions {
+		sb.WriteString(fmt.Sprintf("# used %s times, saves ~%s keystrokes\n", FormatNumber(s.Count), FormatNumber(s.Saved)))
+		if s.Function {
+			sb.WriteString(shellFunction(shell, s.Name, s.Command))
+		} else {
+			sb.WriteString(shellAlias(shell, s.Name, s.Command))
+		}
+	}
+
+	if len(stats.TypoAliases) > 0 {
+		sb.WriteString("\n# common typos\n")
+	}
+	for _, t := range stats.TypoAliases {
+		sb.WriteString(shellAlias(shell, t.Typo, t.Intended))
+	}
+
+	return sb.String(), nil
+}
+
+func shellAlias(shell, name, command string) string {
+	if shell == "fish" {
+		return fmt.Sprintf("alias %s %s\n", name, shellQuote(command))
+	}
+	return fmt.Sprintf("alias %s=%s\n", name, shellQuote(command))
+}
+
+// shellFunction wraps a compound command (pipes, chains) in a function. the
+// function's arguments go to the last stage, the way an alias passes them on
+func shellFunction(shell, name, command string) string {
+	command = strings.TrimRight(strings.TrimSpace(command), "; ")
+	args := ` "$@"`
+	if shell == "fish" {
+		args = " $argv"
+	}
+	// a subshell, group or background job takes no arguments
+	if pipelines := parser.SplitPipelines(command); len(pipelines) > 0 {
+		stages := pipelines[len(pipelines)-1]
+		last := stages[len(stages)-1]
+		if last != "" && !strings.ContainsAny(last[:1], "({") && !strings.HasSuffix(command, "&") {
+			command += args
+		}
+	}
+
+	if shell == "fish" {
+		return fmt.Sprintf("function %s\n    %s\nend\n", name, command)
+	}
+	return fmt.Sprintf("%s() {\n    %s\n}\n", name, command)
+}
+
+// shellQuote wraps s in single quotes, escaping embedded single quotes
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
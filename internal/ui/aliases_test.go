@@ -0,0 +1,21 @@
+package ui
+
+import "testing"
+
+func TestShellFunction(t *testing.T) {
+	tests := []struct {
+		shell, command, want string
+	}{
+		{"zsh", "git log --oneline | head -20", "gl() {\n    git log --oneline | head -20 \"$@\"\n}\n"},
+		{"bash", "make build && ./bin/app;", "gl() {\n    make build && ./bin/app \"$@\"\n}\n"},
+		{"fish", "docker ps -a | grep api", "function gl\n    docker ps -a | grep api $argv\nend\n"},
+		// subshells and background jobs can't take arguments
+		{"zsh", "cd web && (npm ci; npm test)", "gl() {\n    cd web && (npm ci; npm test)\n}\n"},
+		{"zsh", "make watch &", "gl() {\n    make watch &\n}\n"},
+	}
+	for _, tt := range tests {
+		if got := shellFunction(tt.shell, "gl", tt.command); got != tt.want {
+			t.Errorf("shellFunction(%s, %q) = %q, want %q", tt.shell, tt.command, got, tt.want)
+		}
+	}
+}
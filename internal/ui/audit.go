@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+// auditReport is the JSON shape of the dangerous command audit
+type auditReport struct {
+	TotalCommands int                    `json:"total_commands"`
+	RiskyCommands int                    `json:"risky_commands"`
+	Findings      []analyzer.RiskFinding `json:"findings"`
+}
+
+// renderAudit produces the dangerous command audit as "text" or "json"
+func RenderAudit(stats *analyzer.Stats, format string) (string, error) {
+	switch format {
+	case "json":
+		report := auditReport{
+			TotalCommands: stats.TotalCommands,
+			RiskyCommands: stats.RiskyCount,
+			Findings:      stats.RiskFindings,
+		}
+		if report.Findings == nil {
+			report.Findings = []analyzer.RiskFinding{}
+		}
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	case "text":
+		return renderAuditText(stats), nil
+	default:
+		return "", fmt.Errorf("unsupported audit format %q (use text or json)", format)
+	}
+}
+
+func renderAuditText(stats *analyzer.Stats) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Dangerous command audit: %s of %s commands matched a rule (%.2f%%)\n\n",
+		FormatNumber(stats.RiskyCount), FormatNumber(stats.TotalCommands), stats.RiskyPct)
+
+	if len(stats.RiskFindings) == 0 {
+		sb.WriteString("No risky commands found.\n")
+		return sb.String()
+	}
+
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "RULE\tSEVERITY\tCOUNT\tFIRST\tLAST\tEXAMPLE")
+	for _, f := range stats.RiskFindings {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n", f.Rule, f.Severity, f.Count,
+			formatAuditTime(f.First), formatAuditTime(f.Last), TruncateString(f.Example, 60))
+	}
+	w.Flush()
+	return sb.String()
+}
+
+func formatAuditTime(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04")
+}
@@ -0,0 +1,211 @@
+package ui
+
+import (
+	"fmt"
+	"html"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+const (
+	// share card size (matches the common social preview ratio)
+	CardWidth  = 1200
+	CardHeight = 630
+)
+
+var cardBackground = lipgloss.Color("#111318")
+
+// cardShape is a drawing primitive; the card is laid out once and then
+// rasterized to PNG or serialized to SVG so both outputs match
+type cardShape struct {
+	text   string // empty for rectangles
+	x, y   int    // top-left corner
+	w, h   int    // rectangle size
+	scale  int    // text pixel size
+	radius int
+	color  lipgloss.Color
+}
+
+func cardRect(x, y, w, h, radius int, c lipgloss.Color) cardShape {
+	return cardShape{x: x, y: y, w: w, h: h, radius: radius, color: c}
+}
+
+func cardText(x, y, scale int, c lipgloss.Color, text string) cardShape {
+	return cardShape{text: text, x: x, y: y, scale: scale, color: c}
+}
+
+func cardTextWidth(text string, scale int) int {
+	return len([]rune(text)) * glyphAdvance * scale
+}
+
+// layoutCard builds the fixed-size share card
+func layoutCard(stats *analyzer.Stats, arch *analyzer.Archetype) []cardShape {
+	shapes := []cardShape{cardRect(0, 0, CardWidth, CardHeight, 0, cardBackground)}
+
+	// title with the same gradient as the terminal header
+	gradient := []lipgloss.Color{ColorPrimary, ColorOrange, ColorAccent, ColorGreen, ColorSecondary, ColorBlue}
+	title := "TERMINAL WRAPPED"
+	for i, r := range title {
+		c := gradient[i*len(gradient)/len(title)]
+		shapes = append(shapes, cardText(60+i*glyphAdvance*4, 40, 4, c, string(r)))
+	}
+
+	// archetype
+	shapes = append(shapes, cardText(60, 110, 3, ColorMuted, "YOUR ARCHETYPE"))
+	shapes = append(shapes, cardText(60, 140, 6, ColorAccent, arch.Icon))
+	shapes = append(shapes, cardText(60+cardTextWidth(arch.Icon+" ", 6), 140, 6, ColorBright, arch.Name))
+	shapes = append(shapes, cardText(60, 200, 3, ColorMuted, "\""+arch.Tagline+"\""))
+
+	shapes = append(shapes, cardRect(60, 240, CardWidth-120, 2, 0, ColorDim))
+
+	// total commands
+	shapes = append(shapes, cardText(60, 265, 3, ColorMuted, "TOTAL COMMANDS"))
+	shapes = append(shapes, cardText(60, 295, 8, ColorAccent, FormatNumber(stats.TotalCommands)))
+
+	// top 5 commands
+	shapes = append(shapes, cardText(60, 370, 3, ColorMuted, "TOP COMMANDS"))
+	maxCount := 1
+	if len(stats.TopCommands) > 0 {
+		maxCount = stats.TopCommands[0].Count
+	}
+	for i, cmd := range stats.TopCommands {
+		if i >= 5 {
+			break
+		}
+		y := 405 + i*34
+		name := TruncateString(cmd.Command, 8)
+		shapes = append(shapes, cardText(60, y, 3, ColorBright, fmt.Sprintf("%d. %s", i+1, name)))
+		barWidth := max(cmd.Count*220/maxCount, 4)
+		shapes = append(shapes, cardRect(272, y, 220, 21, 4, HeatmapColors[0]))
+		shapes = append(shapes, cardRect(272, y, barWidth, 21, 4, getCmdColor(cmd.Command)))
+		shapes = append(shapes, cardText(505, y, 3, ColorMuted, FormatNumber(cmd.Count)))
+	}
+
+	// heatmap
+	shapes = append(shapes, cardText(640, 265, 3, ColorMuted, "ACTIVITY"))
+	if stats.HasTimeData {
+		maxVal := 1
+		for day := 0; day < 7; day++ {
+			for hour := 0; hour < 24; hour++ {
+				maxVal = max(maxVal, stats.HeatMap[day][hour])
+			}
+		}
+		for day := 0; day < 7; day++ {
+			y := 300 + day*21
+			shapes = append(shapes, cardText(640, y+3, 2, ColorDim, analyzer.GetDayName(day)[:2]))
+			for hour := 0; hour < 24; hour++ {
+				c := HeatmapColors[heatmapColorIndex(stats.HeatMap[day][hour], maxVal)]
+				shapes = append(shapes, cardRect(672+hour*21, y, 18, 18, 3, c))
+			}
+		}
+		peak := fmt.Sprintf("PEAK: %s %02d:00", analyzer.GetDayName(stats.PeakDay), stats.PeakHour)
+		shapes = append(shapes, cardText(672, 460, 3, ColorAccent, peak))
+	} else {
+		shapes = append(shapes, cardText(640, 300, 3, ColorDim, "NO TIMESTAMP DATA"))
+	}
+
+	// footer
+	shapes = append(shapes, cardText(60, 585, 3, ColorAccent, "#TerminalWrapped"))
+	repo := "github.com/Anish-Reddy-K/terminal-wrapped"
+	shapes = append(shapes, cardText(CardWidth-60-cardTextWidth(repo, 2), 590, 2, ColorDim, repo))
+
+	return shapes
+}
+
+// renderCardSVG renders the share card as a standalone SVG document
+func RenderCardSVG(stats *analyzer.Stats, arch *analyzer.Archetype) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n",
+		CardWidth, CardHeight, CardWidth, CardHeight)
+
+	for _, s := range layoutCard(stats, arch) {
+		if s.text == "" {
+			fmt.Fprintf(&sb, `  <rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s"/>`+"\n",
+				s.x, s.y, s.w, s.h, s.radius, s.color)
+			continue
+		}
+		// baseline sits at the bottom of the 7px glyph cell; textLength keeps
+		// the monospace width identical to the PNG layout
+		fmt.Fprintf(&sb, `  <text x="%d" y="%d" font-family="Menlo, Consolas, monospace" font-size="%d" font-weight="bold" fill="%s" textLength="%d" xml:space="preserve">%s</text>`+"\n",
+			s.x, s.y+glyphHeight*s.scale, (glyphHeight+2)*s.scale, s.color,
+			cardTextWidth(s.text, s.scale)-s.scale, html.EscapeString(s.text))
+	}
+
+	sb.WriteString("</svg>\n")
+	return sb.String()
+}
+
+// renderCardPNG rasterizes the share card and encodes it as PNG
+func RenderCardPNG(w io.Writer, stats *analyzer.Stats, arch *analyzer.Archetype) error {
+	img := image.NewRGBA(image.Rect(0, 0, CardWidth, CardHeight))
+
+	for _, s := range layoutCard(stats, arch) {
+		c := hexColor(s.color)
+		if s.text == "" {
+			fillRoundedRect(img, s.x, s.y, s.w, s.h, s.radius, c)
+			continue
+		}
+		x := s.x
+		for _, r := range s.text {
+			rows := glyph(r)
+			for gy, row := range rows {
+				for gx, px := range row {
+					if px == '#' {
+						fillRoundedRect(img, x+gx*s.scale, s.y+gy*s.scale, s.scale, s.scale, 0, c)
+					}
+				}
+			}
+			x += glyphAdvance * s.scale
+		}
+	}
+
+	return png.Encode(w, img)
+}
+
+func fillRoundedRect(img *image.RGBA, x0, y0, w, h, radius int, c color.RGBA) {
+	for y := y0; y < y0+h; y++ {
+		for x := x0; x < x0+w; x++ {
+			if radius > 0 && outsideCorner(x-x0, y-y0, w, h, radius) {
+				continue
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+}
+
+// outsideCorner reports whether a pixel falls outside the rounded corners of a rect
+func outsideCorner(x, y, w, h, r int) bool {
+	var cx, cy int
+	switch {
+	case x < r && y < r:
+		cx, cy = r, r
+	case x >= w-r && y < r:
+		cx, cy = w-r-1, r
+	case x < r && y >= h-r:
+		cx, cy = r, h-r-1
+	case x >= w-r && y >= h-r:
+		cx, cy = w-r-1, h-r-1
+	default:
+		return false
+	}
+	dx, dy := x-cx, y-cy
+	return dx*dx+dy*dy > r*r
+}
+
+// hexColor converts a "#RRGGBB" palette color to an RGBA value
+func hexColor(c lipgloss.Color) color.RGBA {
+	hex := strings.TrimPrefix(string(c), "#")
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil || len(hex) != 6 {
+		return color.RGBA{A: 0xff}
+	}
+	return color.RGBA{R: uint8(v >> 16), G: uint8(v >> 8), B: uint8(v), A: 0xff}
+}
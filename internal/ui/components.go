@@ -18,6 +18,7 @@ var (
 	ColorGreen     = lipgloss.Color("#10B981") // Green
 	ColorOrange    = lipgloss.Color("#F97316") // Orange
 	ColorPink      = lipgloss.Color("#EC4899") // Pink
+	ColorIndigo    = lipgloss.Color("#818CF8") // Indigo
 
 	// neutral colors
 	ColorDim    = lipgloss.Color("#6B7280") // Gray
@@ -79,6 +80,7 @@ var (
 		"Search":     ColorPink,
 		"Network":    ColorAccent,
 		"Files":      ColorPrimary,
+		"Cloud":      ColorIndigo,
 	}
 )
 
@@ -100,6 +102,25 @@ func ProgressBar(value, max int, width int, color lipgloss.Color) string {
 	return bar
 }
 
+// sparkline renders values as a row of block characters scaled to the maximum
+func Sparkline(values []int, color lipgloss.Color) string {
+	levels := []rune("▁▂▃▄▅▆▇█")
+	maxVal := 0
+	for _, v := range values {
+		maxVal = max(maxVal, v)
+	}
+
+	var sb strings.Builder
+	for _, v := range values {
+		idx := 0
+		if maxVal > 0 {
+			idx = v * (len(levels) - 1) / maxVal
+		}
+		sb.WriteRune(levels[idx])
+	}
+	return lipgloss.NewStyle().Foreground(color).Render(sb.String())
+}
+
 // miniBar creates a compact 4-block progress bar for categories
 func MiniBar(pct float64, color lipgloss.Color) string {
 	blocks := int(pct / 10) // 10% per block, max 10 blocks but we cap at 4 for display
@@ -152,16 +173,7 @@ func Heatmap(data [7][24]int) string {
 			sum := blocks[day][block]
 
 			// normalize and get color
-			var colorIdx int
-			if sum == 0 {
-				colorIdx = 0
-			} else {
-				intensity := float64(sum) / float64(maxVal)
-				colorIdx = 1 + int(intensity*float64(len(HeatmapColors)-2))
-				if colorIdx >= len(HeatmapColors) {
-					colorIdx = len(HeatmapColors) - 1
-				}
-			}
+			colorIdx := heatmapColorIndex(sum, maxVal)
 
 			blockStyle := lipgloss.NewStyle().Foreground(HeatmapColors[colorIdx])
 			sb.WriteString(blockStyle.Render("## "))
@@ -172,6 +184,19 @@ func Heatmap(data [7][24]int) string {
 	return sb.String()
 }
 
+// heatmapColorIndex maps a count onto the HeatmapColors scale
+func heatmapColorIndex(count, maxVal int) int {
+	if count == 0 {
+		return 0
+	}
+	intensity := float64(count) / float64(maxVal)
+	idx := 1 + int(intensity*float64(len(HeatmapColors)-2))
+	if idx >= len(HeatmapColors) {
+		idx = len(HeatmapColors) - 1
+	}
+	return idx
+}
+
 // formatNumber formats a number with commas
 func FormatNumber(n int) string {
 	if n < 0 {
@@ -194,6 +219,14 @@ func FormatNumber(n int) string {
 	return result.String()
 }
 
+// formatPlural formats a count with its noun, adding an s unless n is one
+func FormatPlural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return FormatNumber(n) + " " + noun + "s"
+}
+
 // truncateString truncates a string to max length with ellipsis
 func TruncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
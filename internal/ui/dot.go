@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+// renderDOT exports the command transition graph in Graphviz DOT format
+func RenderDOT(stats *analyzer.Stats) string {
+	var sb strings.Builder
+	sb.WriteString("digraph workflows {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString(fmt.Sprintf("  bgcolor=%q;\n", "#111318"))
+	sb.WriteString(fmt.Sprintf("  node [shape=box, style=\"rounded,filled\", fontname=\"Menlo\", fontcolor=%q, color=%q];\n", string(ColorBright), string(ColorDim)))
+	sb.WriteString(fmt.Sprintf("  edge [fontname=\"Menlo\", fontcolor=%q, color=%q];\n", string(ColorMuted), string(ColorDim)))
+
+	maxCount := 1
+	nodes := make(map[string]bool)
+	for _, t := range stats.Transitions {
+		maxCount = max(maxCount, t.Count)
+		nodes[t.From] = true
+		nodes[t.To] = true
+	}
+
+	names := make([]string, 0, len(nodes))
+	for name := range nodes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		base, _, _ := strings.Cut(name, " ")
+		color, ok := CategoryColors[analyzer.CategoryOf(base)]
+		if !ok {
+			color = ColorDim
+		}
+		sb.WriteString(fmt.Sprintf("  %q [fillcolor=%q];\n", name, string(color)+"55"))
+	}
+
+	for _, t := range stats.Transitions {
+		width := 1 + 4*float64(t.Count)/float64(maxCount)
+		sb.WriteString(fmt.Sprintf("  %q -> %q [label=%q, penwidth=%.1f];\n", t.From, t.To, FormatNumber(t.Count), width))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
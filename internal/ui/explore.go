@@ -0,0 +1,592 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// explorer panes
+const (
+	paneCommands = iota
+	paneCategories
+	paneTime
+	paneCount
+)
+
+var paneNames = []string{"Commands", "Categories", "Time"}
+
+// explorerItem is one row of a pane list
+type explorerItem struct {
+	label string
+	count int
+}
+
+// explorer is the interactive history browser
+type explorer struct {
+	data *parser.HistoryData
+
+	// per command, worked out once: base command and category
+	bases      []string
+	categories []string
+
+	// filters
+	search   string
+	category string
+	from, to time.Time // inclusive day range, zero = open ended
+
+	// navigation
+	pane   int
+	cursor [paneCount]int
+	offset [paneCount]int
+	detail []string // drill-down lines, nil when browsing lists
+	scroll int
+
+	// prompt input ("search" or "date"), empty when not typing
+	prompt string
+	input  string
+	notice string
+
+	// derived from the current filters
+	matches    []int // indexes into data.Commands
+	lists      [paneCount][]explorerItem
+	stats      *analyzer.Stats // time summary of the matches, nil until shown
+	dateLayout string
+}
+
+// runExplorer opens a full-screen browser over the parsed history with
+// panes for commands, categories and time, plus search and date filters
+func RunExplorer(data *parser.HistoryData) error {
+	if !IsInteractive() {
+		return errors.New("the explorer needs an interactive terminal")
+	}
+
+	e := newExplorer(data)
+
+	scr, err := openScreen()
+	if err != nil {
+		return err
+	}
+	defer scr.close()
+
+	keys := scr.keys()
+	for {
+		width, height := scr.size()
+		scr.draw(e.view(width, height))
+
+		key, ok := <-keys
+		if !ok || !e.handle(key, height) {
+			return nil
+		}
+	}
+}
+
+// newExplorer indexes the history once so filters don't reparse it
+func newExplorer(data *parser.HistoryData) *explorer {
+	e := &explorer{
+		data:       data,
+		bases:      make([]string, len(data.Commands)),
+		categories: make([]string, len(data.Commands)),
+		dateLayout: "2006-01-02",
+	}
+	for i := range data.Commands {
+		e.bases[i] = parser.GetBaseCommand(&data.Commands[i])
+		e.categories[i] = explorerCategory(e.bases[i])
+	}
+	e.apply()
+	return e
+}
+
+// apply refilters the commands and recounts the panes. it runs once per
+// filter change; the time summary waits until the time pane is shown
+func (e *explorer) apply() {
+	e.matches = e.matches[:0]
+	needle := strings.ToLower(e.search)
+
+	// compare calendar days in the zone each command was typed in
+	var fromDay, toDay string
+	if !e.from.IsZero() {
+		fromDay = e.from.Format(e.dateLayout)
+	}
+	if !e.to.IsZero() {
+		toDay = e.to.Format(e.dateLayout)
+	}
+
+	for i := range e.data.Commands {
+		cmd := &e.data.Commands[i]
+		if e.category != "" && e.categories[i] != e.category {
+			continue
+		}
+		if fromDay != "" || toDay != "" {
+			if !cmd.HasTime {
+				continue
+			}
+			day := cmd.Timestamp.Format(e.dateLayout)
+			if (fromDay != "" && day < fromDay) || (toDay != "" && day > toDay) {
+				continue
+			}
+		}
+		if needle != "" && !strings.Contains(strings.ToLower(cmd.Raw), needle) {
+			continue
+		}
+		e.matches = append(e.matches, i)
+	}
+
+	commandCounts := make(map[string]int)
+	categoryCounts := make(map[string]int)
+	monthCounts := make(map[string]int)
+	for _, i := range e.matches {
+		commandCounts[e.bases[i]]++
+		categoryCounts[e.categories[i]]++
+		if cmd := &e.data.Commands[i]; cmd.HasTime {
+			monthCounts[cmd.Timestamp.Format("2006-01")]++
+		}
+	}
+
+	e.lists[paneCommands] = sortedItems(commandCounts, false)
+	e.lists[paneCategories] = sortedItems(categoryCounts, false)
+	e.lists[paneTime] = sortedItems(monthCounts, true)
+	e.stats = nil
+
+	for p := range e.cursor {
+		e.cursor[p] = min(e.cursor[p], max(len(e.lists[p])-1, 0))
+		e.offset[p] = min(e.offset[p], e.cursor[p])
+	}
+}
+
+// timeStats analyzes the matching commands for the time pane, at most
+// once per filter change
+func (e *explorer) timeStats() *analyzer.Stats {
+	if e.stats == nil {
+		e.stats, _ = analyzer.AnalyzeStream(&matchStream{commands: e.data.Commands, matches: e.matches},
+			e.data.ParsedAt, analyzer.DefaultOptions())
+	}
+	return e.stats
+}
+
+// matchStream streams the commands that match the filters
+type matchStream struct {
+	commands []parser.Command
+	matches  []int
+	next     int
+}
+
+func (s *matchStream) Scan() bool {
+	if s.next >= len(s.matches) {
+		return false
+	}
+	s.next++
+	return true
+}
+
+func (s *matchStream) Command() *parser.Command { return &s.commands[s.matches[s.next-1]] }
+
+func (s *matchStream) Err() error { return nil }
+
+// explorerCategory is analyzer.CategoryOf with a bucket for everything else
+func explorerCategory(base string) string {
+	if category := analyzer.CategoryOf(base); category != "" {
+		return category
+	}
+	return "Other"
+}
+
+// sortedItems orders by count (or by label for chronological lists)
+func sortedItems(counts map[string]int, byLabel bool) []explorerItem {
+	items := make([]explorerItem, 0, len(counts))
+	for label, count := range counts {
+		items = append(items, explorerItem{label, count})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !byLabel && items[i].count != items[j].count {
+			return items[i].count > items[j].count
+		}
+		return items[i].label < items[j].label
+	})
+	return items
+}
+
+// handle processes one key press, returning false to quit
+func (e *explorer) handle(key string, height int) bool {
+	e.notice = ""
+
+	if e.prompt != "" {
+		e.handlePrompt(key)
+		return true
+	}
+
+	page := max(e.listHeight(height)-1, 1)
+
+	if e.detail != nil {
+		switch key {
+		case "q", "ctrl+c":
+			return false
+		case "esc", "backspace", "left", "h":
+			e.detail = nil
+		case "up", "k":
+			e.scroll = max(e.scroll-1, 0)
+		case "down", "j":
+			e.scroll = min(e.scroll+1, max(len(e.detail)-1, 0))
+		case "pgup":
+			e.scroll = max(e.scroll-page, 0)
+		case "pgdown":
+			e.scroll = min(e.scroll+page, max(len(e.detail)-1, 0))
+		}
+		return true
+	}
+
+	items := e.lists[e.pane]
+	switch key {
+	case "q", "ctrl+c":
+		return false
+	case "tab", "right", "l":
+		e.pane = (e.pane + 1) % paneCount
+	case "left", "h":
+		e.pane = (e.pane + paneCount - 1) % paneCount
+	case "1", "2", "3":
+		e.pane = int(key[0] - '1')
+	case "up", "k":
+		e.cursor[e.pane] = max(e.cursor[e.pane]-1, 0)
+	case "down", "j":
+		e.cursor[e.pane] = min(e.cursor[e.pane]+1, max(len(items)-1, 0))
+	case "pgup":
+		e.cursor[e.pane] = max(e.cursor[e.pane]-page, 0)
+	case "pgdown":
+		e.cursor[e.pane] = min(e.cursor[e.pane]+page, max(len(items)-1, 0))
+	case "home":
+		e.cursor[e.pane] = 0
+	case "end":
+		e.cursor[e.pane] = max(len(items)-1, 0)
+	case "/":
+		e.prompt, e.input = "search", e.search
+	case "d":
+		e.prompt, e.input = "date", e.formatRange()
+	case "r", "esc":
+		e.search, e.category = "", ""
+		e.from, e.to = time.Time{}, time.Time{}
+		e.apply()
+	case "enter":
+		if len(items) > 0 {
+			e.drill(items[e.cursor[e.pane]].label)
+		}
+	}
+
+	// keep the cursor on screen
+	visible := e.listHeight(height)
+	if e.cursor[e.pane] < e.offset[e.pane] {
+		e.offset[e.pane] = e.cursor[e.pane]
+	} else if e.cursor[e.pane] >= e.offset[e.pane]+visible {
+		e.offset[e.pane] = e.cursor[e.pane] - visible + 1
+	}
+	return true
+}
+
+func (e *explorer) handlePrompt(key string) {
+	switch key {
+	case "esc", "ctrl+c":
+		e.prompt = ""
+	case "backspace":
+		if len(e.input) > 0 {
+			e.input = e.input[:len(e.input)-1]
+		}
+	case "enter":
+		switch e.prompt {
+		case "search":
+			e.search = e.input
+		case "date":
+			from, to, err := parseDateRange(e.input, e.dateLayout)
+			if err != nil {
+				e.notice = err.Error()
+				return
+			}
+			e.from, e.to = from, to
+		}
+		e.prompt = ""
+		e.apply()
+	default:
+		if len(key) == 1 {
+			e.input += key
+		}
+	}
+}
+
+// drill narrows the view on the selected row: a command opens its detail,
+// a category or month becomes a filter on the commands pane
+func (e *explorer) drill(label string) {
+	switch e.pane {
+	case paneCommands:
+		e.detail = e.commandDetail(label)
+		e.scroll = 0
+	case paneCategories:
+		e.category = label
+		e.pane = paneCommands
+		e.cursor[paneCommands] = 0
+		e.apply()
+	case paneTime:
+		month, err := time.Parse("2006-01", label)
+		if err != nil {
+			return
+		}
+		e.from, e.to = month, month.AddDate(0, 1, -1)
+		e.pane = paneCommands
+		e.cursor[paneCommands] = 0
+		e.apply()
+	}
+}
+
+// commandDetail lists subcommands, busiest days and every invocation of a command
+func (e *explorer) commandDetail(base string) []string {
+	subcommands := make(map[string]int)
+	days := make(map[string]int)
+	var invocations []parser.Command
+
+	for _, i := range e.matches {
+		if e.bases[i] != base {
+			continue
+		}
+		cmd := &e.data.Commands[i]
+		invocations = append(invocations, *cmd)
+		if sub := firstNonFlag(cmd, base); sub != "" {
+			subcommands[sub]++
+		}
+		if cmd.HasTime {
+			days[cmd.Timestamp.Format("2006-01-02 Mon")]++
+		}
+	}
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	lines := []string{
+		headerStyle.Render(fmt.Sprintf("-- %s ", base)) + LabelStyle.Render(fmt.Sprintf("%s invocations", FormatNumber(len(invocations)))),
+		"",
+	}
+
+	section := func(title string, items []explorerItem, limit int) {
+		if len(items) == 0 {
+			return
+		}
+		lines = append(lines, headerStyle.Render("-- "+title+" "))
+		for i, item := range items {
+			if i >= limit {
+				break
+			}
+			lines = append(lines, fmt.Sprintf("  %s %s %s",
+				ValueStyle.Render(padRight(TruncateString(item.label, 24), 24)),
+				ProgressBar(item.count, items[0].count, 20, getCmdColor(base)),
+				LabelStyle.Render(FormatNumber(item.count))))
+		}
+		lines = append(lines, "")
+	}
+	section("SUBCOMMANDS", sortedItems(subcommands, false), 15)
+	section("BUSIEST DAYS", sortedItems(days, false), 10)
+
+	lines = append(lines, headerStyle.Render("-- INVOCATIONS (newest first) "))
+	for i := len(invocations) - 1; i >= 0; i-- {
+		when := "                "
+		if invocations[i].HasTime {
+			when = invocations[i].Timestamp.Format("2006-01-02 15:04")
+		}
+		lines = append(lines, "  "+SubtleStyle.Render(when)+"  "+invocations[i].Raw)
+	}
+	return lines
+}
+
+// firstNonFlag returns the first argument after the base command that is not a flag
+func firstNonFlag(cmd *parser.Command, base string) string {
+	args := cmd.Args
+	// skip past wrappers such as sudo so the subcommand belongs to base
+	for i, arg := range args {
+		if arg == base {
+			args = args[i+1:]
+			break
+		}
+	}
+	for _, arg := range args {
+		if !strings.HasPrefix(arg, "-") {
+			return arg
+		}
+	}
+	return ""
+}
+
+func (e *explorer) listHeight(height int) int {
+	return max(height-8, 3)
+}
+
+func (e *explorer) formatRange() string {
+	if e.from.IsZero() && e.to.IsZero() {
+		return ""
+	}
+	var from, to string
+	if !e.from.IsZero() {
+		from = e.from.Format(e.dateLayout)
+	}
+	if !e.to.IsZero() {
+		to = e.to.Format(e.dateLayout)
+	}
+	return from + ".." + to
+}
+
+// parseDateRange parses "FROM..TO" where either side may be empty
+func parseDateRange(s, layout string) (from, to time.Time, err error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return from, to, nil
+	}
+	start, end, found := strings.Cut(s, "..")
+	if !found {
+		end = start
+	}
+	if start = strings.TrimSpace(start); start != "" {
+		if from, err = time.Parse(layout, start); err != nil {
+			return from, to, fmt.Errorf("bad start date %q (want YYYY-MM-DD)", start)
+		}
+	}
+	if end = strings.TrimSpace(end); end != "" {
+		if to, err = time.Parse(layout, end); err != nil {
+			return from, to, fmt.Errorf("bad end date %q (want YYYY-MM-DD)", end)
+		}
+	}
+	if !from.IsZero() && !to.IsZero() && from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start date %s is after end date %s", start, end)
+	}
+	return from, to, nil
+}
+
+// view renders the whole screen
+func (e *explorer) view(width, height int) string {
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	activeTab := lipgloss.NewStyle().Bold(true).Foreground(ColorAccent)
+
+	var lines []string
+
+	// title + filter summary
+	title := headerStyle.Render("TERMINAL WRAPPED · EXPLORER") + "  " +
+		LabelStyle.Render(fmt.Sprintf("%s of %s commands", FormatNumber(len(e.matches)), FormatNumber(len(e.data.Commands))))
+	lines = append(lines, title)
+
+	var filters []string
+	if e.search != "" {
+		filters = append(filters, "search: "+ValueStyle.Render(e.search))
+	}
+	if e.category != "" {
+		filters = append(filters, "category: "+ValueStyle.Render(e.category))
+	}
+	if r := e.formatRange(); r != "" {
+		filters = append(filters, "dates: "+ValueStyle.Render(r))
+	}
+	if len(filters) == 0 {
+		filters = append(filters, "no filters")
+	}
+	lines = append(lines, LabelStyle.Render(strings.Join(filters, "   ")))
+
+	// pane tabs
+	var tabs []string
+	for i, name := range paneNames {
+		label := fmt.Sprintf("%d %s", i+1, name)
+		if i == e.pane && e.detail == nil {
+			tabs = append(tabs, activeTab.Render("["+label+"]"))
+		} else {
+			tabs = append(tabs, SubtleStyle.Render(" "+label+" "))
+		}
+	}
+	lines = append(lines, strings.Join(tabs, " "))
+	lines = append(lines, SubtleStyle.Render(strings.Repeat("-", min(width, TotalWidth))))
+
+	visible := e.listHeight(height)
+	if e.detail != nil {
+		end := min(e.scroll+visible, len(e.detail))
+		lines = append(lines, e.detail[e.scroll:end]...)
+		for i := end - e.scroll; i < visible; i++ {
+			lines = append(lines, "")
+		}
+	} else {
+		body := e.listView(visible)
+		if e.pane == paneTime {
+			body = lipgloss.JoinHorizontal(lipgloss.Top, body, "   ", e.timeSummary())
+		}
+		bodyLines := strings.Split(body, "\n")
+		for i := 0; i < visible; i++ {
+			if i < len(bodyLines) {
+				lines = append(lines, bodyLines[i])
+			} else {
+				lines = append(lines, "")
+			}
+		}
+	}
+
+	lines = append(lines, SubtleStyle.Render(strings.Repeat("-", min(width, TotalWidth))))
+
+	// prompt / help line
+	switch {
+	case e.prompt == "search":
+		lines = append(lines, AccentStyle.Render("search: ")+e.input+"█")
+	case e.prompt == "date":
+		lines = append(lines, AccentStyle.Render("dates (YYYY-MM-DD..YYYY-MM-DD): ")+e.input+"█")
+	case e.notice != "":
+		lines = append(lines, lipgloss.NewStyle().Foreground(ColorPrimary).Render(e.notice))
+	case e.detail != nil:
+		lines = append(lines, SubtleStyle.Render("↑/↓ scroll · esc back · q quit"))
+	default:
+		lines = append(lines, SubtleStyle.Render("tab/←/→ pane · ↑/↓ move · enter drill down · / search · d dates · r reset · q quit"))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (e *explorer) listView(visible int) string {
+	items := e.lists[e.pane]
+	if len(items) == 0 {
+		if e.pane == paneTime && !e.data.HasTimes {
+			return SubtleStyle.Render(" No timestamp data - enable EXTENDED_HISTORY")
+		}
+		return SubtleStyle.Render(" Nothing matches the current filters")
+	}
+
+	maxCount := 0
+	for _, item := range items {
+		maxCount = max(maxCount, item.count)
+	}
+
+	var lines []string
+	start := e.offset[e.pane]
+	end := min(start+visible, len(items))
+	for i := start; i < end; i++ {
+		item := items[i]
+		color := getCmdColor(item.label)
+		if e.pane == paneCategories {
+			if c, ok := CategoryColors[item.label]; ok {
+				color = c
+			} else {
+				color = ColorMuted
+			}
+		}
+
+		marker := "  "
+		label := LabelStyle.Render(padRight(TruncateString(item.label, 18), 18))
+		if i == e.cursor[e.pane] {
+			marker = AccentStyle.Render("> ")
+			label = ValueStyle.Render(padRight(TruncateString(item.label, 18), 18))
+		}
+		lines = append(lines, fmt.Sprintf("%s%s %s %7s", marker, label,
+			ProgressBar(item.count, maxCount, 24, color), FormatNumber(item.count)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// timeSummary shows the heatmap and peak for the filtered commands
+func (e *explorer) timeSummary() string {
+	stats := e.timeStats()
+	if !stats.HasTimeData || stats.TotalCommands == 0 {
+		return ""
+	}
+	peak := AccentStyle.Render(fmt.Sprintf(">> Peak: %s %02d:00", analyzer.GetDayName(stats.PeakDay), stats.PeakHour))
+	streak := LabelStyle.Render(fmt.Sprintf("Streak: %d days  Busiest: %s", stats.LongestStreak, formatBusiestDay(stats)))
+	return strings.TrimSuffix(Heatmap(stats.HeatMap), "\n") + "\n" + peak + "\n" + streak
+}
@@ -0,0 +1,266 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+// exploreData builds a small history: git and ls in January, docker in
+// February, and one untimed command
+func exploreData() *parser.HistoryData {
+	day := func(month time.Month, d int) time.Time { return time.Date(2025, month, d, 10, 0, 0, 0, time.UTC) }
+	command := func(raw string, when time.Time) parser.Command {
+		fields := strings.Fields(raw)
+		return parser.Command{Raw: raw, Command: fields[0], Args: fields[1:], Timestamp: when, HasTime: !when.IsZero()}
+	}
+	return &parser.HistoryData{
+		HasTimes: true,
+		ParsedAt: day(3, 1),
+		Commands: []parser.Command{
+			command("git status", day(1, 5)),
+			command("git commit -m wip", day(1, 5)),
+			command("ls -la", day(1, 20)),
+			command("docker ps", day(2, 3)),
+			command("git push", day(2, 3)),
+			command("sudo git pull", day(2, 10)),
+			command("ls", time.Time{}),
+		},
+	}
+}
+
+// press feeds keys to the explorer, failing if one of them quits
+func press(t *testing.T, e *explorer, keys ...string) {
+	t.Helper()
+	for _, key := range keys {
+		if !e.handle(key, 30) {
+			t.Fatalf("%q quit the explorer", key)
+		}
+	}
+}
+
+// typeText types s into an open prompt and submits it
+func typeText(t *testing.T, e *explorer, s string) {
+	t.Helper()
+	for _, r := range s {
+		press(t, e, string(r))
+	}
+	press(t, e, "enter")
+}
+
+func TestParseDateRange(t *testing.T) {
+	jan1 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	jan31 := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		in       string
+		from, to time.Time
+		err      bool
+	}{
+		{"", time.Time{}, time.Time{}, false},
+		{"   ", time.Time{}, time.Time{}, false},
+		{"..", time.Time{}, time.Time{}, false},
+		{"2025-01-01", jan1, jan1, false},
+		{"2025-01-01..2025-01-31", jan1, jan31, false},
+		{" 2025-01-01 .. 2025-01-31 ", jan1, jan31, false},
+		{"2025-01-01..", jan1, time.Time{}, false},
+		{"..2025-01-31", time.Time{}, jan31, false},
+		{"2025-01-31..2025-01-01", time.Time{}, time.Time{}, true}, // reversed
+		{"2025-13-01", time.Time{}, time.Time{}, true},
+		{"2025-02-30", time.Time{}, time.Time{}, true},
+		{"yesterday", time.Time{}, time.Time{}, true},
+		{"2025-01-01..soon", time.Time{}, time.Time{}, true},
+		{"2025-01-01...2025-01-31", time.Time{}, time.Time{}, true},
+		{"2025/01/01", time.Time{}, time.Time{}, true},
+	}
+	for _, tt := range tests {
+		from, to, err := parseDateRange(tt.in, "2006-01-02")
+		if (err != nil) != tt.err {
+			t.Errorf("parseDateRange(%q) error = %v, want error %v", tt.in, err, tt.err)
+			continue
+		}
+		if err == nil && (!from.Equal(tt.from) || !to.Equal(tt.to)) {
+			t.Errorf("parseDateRange(%q) = %v..%v, want %v..%v", tt.in, from, to, tt.from, tt.to)
+		}
+	}
+}
+
+func TestFirstNonFlag(t *testing.T) {
+	tests := []struct {
+		cmd  parser.Command
+		base string
+		want string
+	}{
+		{parser.Command{Command: "git", Args: []string{"commit", "-m", "x"}}, "git", "commit"},
+		{parser.Command{Command: "sudo", Args: []string{"-E", "apt", "install", "jq"}}, "apt", "install"},
+		{parser.Command{Command: "ls", Args: []string{"-la"}}, "ls", ""},
+		{parser.Command{Command: "ls"}, "ls", ""},
+	}
+	for _, tt := range tests {
+		if got := firstNonFlag(&tt.cmd, tt.base); got != tt.want {
+			t.Errorf("firstNonFlag(%s %v) = %q, want %q", tt.cmd.Command, tt.cmd.Args, got, tt.want)
+		}
+	}
+}
+
+func TestExplorerFilters(t *testing.T) {
+	e := newExplorer(exploreData())
+	if len(e.matches) != 7 {
+		t.Fatalf("unfiltered explorer has %d matches, want 7", len(e.matches))
+	}
+	if top := e.lists[paneCommands][0]; top.label != "git" || top.count != 4 {
+		t.Errorf("top command = %+v, want git x4", top)
+	}
+
+	// search is case-insensitive on the raw line
+	press(t, e, "/")
+	typeText(t, e, "GIT P")
+	if len(e.matches) != 2 {
+		t.Errorf("search 'GIT P' matched %d commands, want git push and git pull", len(e.matches))
+	}
+
+	// dates drop untimed commands and are inclusive
+	press(t, e, "r", "d")
+	typeText(t, e, "2025-02-03..2025-02-10")
+	if len(e.matches) != 3 {
+		t.Errorf("February range matched %d commands, want 3", len(e.matches))
+	}
+	if e.formatRange() != "2025-02-03..2025-02-10" {
+		t.Errorf("formatRange() = %q", e.formatRange())
+	}
+
+	// a bad range keeps the prompt open and the old filter
+	press(t, e, "d")
+	for range len(e.input) {
+		press(t, e, "backspace")
+	}
+	typeText(t, e, "2025-02-10..2025-02-03")
+	if e.prompt != "date" || !strings.Contains(e.notice, "after") {
+		t.Errorf("reversed range: prompt %q, notice %q; want the prompt open with an error", e.prompt, e.notice)
+	}
+	if len(e.matches) != 3 {
+		t.Errorf("reversed range changed the filter to %d matches", len(e.matches))
+	}
+	press(t, e, "esc")
+	if e.prompt != "" {
+		t.Error("esc didn't close the prompt")
+	}
+
+	// reset clears everything
+	press(t, e, "r")
+	if len(e.matches) != 7 || e.formatRange() != "" {
+		t.Errorf("reset left %d matches and range %q", len(e.matches), e.formatRange())
+	}
+}
+
+func TestExplorerDrillDown(t *testing.T) {
+	e := newExplorer(exploreData())
+
+	// a category becomes a filter on the commands pane
+	press(t, e, "2")
+	for i, item := range e.lists[paneCategories] {
+		if item.label == "Containers" {
+			e.cursor[paneCategories] = i
+		}
+	}
+	category := e.lists[paneCategories][e.cursor[paneCategories]].label
+	press(t, e, "enter")
+	if e.pane != paneCommands || e.category != category {
+		t.Fatalf("drilling into %s: pane %d, category %q", category, e.pane, e.category)
+	}
+	for _, i := range e.matches {
+		if e.categories[i] != category {
+			t.Errorf("%q left in the %s filter", e.data.Commands[i].Raw, category)
+		}
+	}
+
+	// a month becomes a date range
+	press(t, e, "r", "3")
+	if got := e.lists[paneTime]; len(got) != 2 || got[0].label != "2025-01" {
+		t.Fatalf("months = %+v, want January and February in order", got)
+	}
+	press(t, e, "down", "enter")
+	if e.formatRange() != "2025-02-01..2025-02-28" || len(e.matches) != 3 {
+		t.Errorf("drilling into February: range %q, %d matches", e.formatRange(), len(e.matches))
+	}
+
+	// a command opens its detail, esc goes back
+	press(t, e, "r", "1", "home", "enter")
+	if len(e.detail) == 0 || !strings.Contains(e.detail[0], "git") || !strings.Contains(e.detail[0], "4 invocations") {
+		t.Fatalf("git detail = %q", e.detail)
+	}
+	if !strings.Contains(strings.Join(e.detail, "\n"), "pull") {
+		t.Error("sudo git pull missing from git's subcommands")
+	}
+	press(t, e, "down", "down")
+	if e.scroll != 2 {
+		t.Errorf("scroll = %d, want 2", e.scroll)
+	}
+	press(t, e, "esc")
+	if e.detail != nil {
+		t.Error("esc didn't leave the detail view")
+	}
+}
+
+func TestExplorerNavigation(t *testing.T) {
+	e := newExplorer(exploreData())
+
+	press(t, e, "tab", "tab", "tab")
+	if e.pane != paneCommands {
+		t.Errorf("tab three times ends on pane %d, want it to wrap", e.pane)
+	}
+	press(t, e, "left")
+	if e.pane != paneTime {
+		t.Errorf("left from the first pane ends on %d, want the last", e.pane)
+	}
+
+	press(t, e, "1", "up")
+	if e.cursor[paneCommands] != 0 {
+		t.Errorf("cursor moved above the list: %d", e.cursor[paneCommands])
+	}
+	press(t, e, "end", "down", "pgdown")
+	if last := len(e.lists[paneCommands]) - 1; e.cursor[paneCommands] != last {
+		t.Errorf("cursor = %d, want it stuck on the last row %d", e.cursor[paneCommands], last)
+	}
+
+	// filtering keeps the cursor inside the shorter list
+	press(t, e, "/")
+	typeText(t, e, "docker")
+	if e.cursor[paneCommands] != 0 {
+		t.Errorf("cursor = %d after filtering to one command", e.cursor[paneCommands])
+	}
+
+	// nothing matching still navigates and drills safely
+	press(t, e, "/", "backspace", "backspace", "backspace", "backspace", "backspace", "backspace")
+	typeText(t, e, "zzz")
+	press(t, e, "down", "enter", "end")
+	if len(e.matches) != 0 || e.detail != nil {
+		t.Errorf("empty filter: %d matches, detail %v", len(e.matches), e.detail)
+	}
+
+	if e.handle("q", 30) {
+		t.Error("q didn't quit")
+	}
+}
+
+func TestExplorerTimeStatsCached(t *testing.T) {
+	e := newExplorer(exploreData())
+	if e.stats != nil {
+		t.Fatal("time stats computed before the time pane was shown")
+	}
+	stats := e.timeStats()
+	if stats.TotalCommands != 7 || e.timeStats() != stats {
+		t.Errorf("time stats = %d commands, recomputed: %v", stats.TotalCommands, e.timeStats() != stats)
+	}
+
+	press(t, e, "/")
+	typeText(t, e, "git")
+	if e.stats != nil {
+		t.Fatal("time stats kept across a filter change")
+	}
+	if got := e.timeStats().TotalCommands; got != 4 {
+		t.Errorf("time stats after filtering cover %d commands, want 4", got)
+	}
+}
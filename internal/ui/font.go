@@ -0,0 +1,91 @@
+package ui
+
+import "unicode"
+
+// 5x7 bitmap font used for the PNG share card, since the standard library
+// ships no font rasterizer. lowercase letters are drawn as uppercase and
+// unknown runes fall back to '?'.
+const (
+	glyphWidth   = 5
+	glyphHeight  = 7
+	glyphAdvance = glyphWidth + 1
+)
+
+var fontGlyphs = map[rune][glyphHeight]string{
+	'A': {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B': {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C': {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D': {"####.", "#...#", "#...#", "#...#", "#...#", "#...#", "####."},
+	'E': {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F': {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G': {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H': {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I': {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J': {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K': {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L': {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M': {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N': {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O': {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P': {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q': {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R': {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S': {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T': {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U': {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V': {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W': {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X': {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y': {"#...#", "#...#", ".#.#.", "..#..", "..#..", "..#..", "..#.."},
+	'Z': {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+
+	'0': {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1': {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2': {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3': {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4': {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5': {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6': {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7': {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8': {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9': {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+
+	' ':  {".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'.':  {".....", ".....", ".....", ".....", ".....", ".##..", ".##.."},
+	',':  {".....", ".....", ".....", ".....", ".##..", "..#..", ".#..."},
+	':':  {".....", ".##..", ".##..", ".....", ".##..", ".##..", "....."},
+	';':  {".....", ".##..", ".##..", ".....", ".##..", "..#..", ".#..."},
+	'!':  {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#.."},
+	'?':  {".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."},
+	'-':  {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'+':  {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	'=':  {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+	'#':  {".#.#.", ".#.#.", "#####", ".#.#.", "#####", ".#.#.", ".#.#."},
+	'/':  {".....", "....#", "...#.", "..#..", ".#...", "#....", "....."},
+	'\\': {".....", "#....", ".#...", "..#..", "...#.", "....#", "....."},
+	'<':  {"...#.", "..#..", ".#...", "#....", ".#...", "..#..", "...#."},
+	'>':  {".#...", "..#..", "...#.", "....#", "...#.", "..#..", ".#..."},
+	'~':  {".....", ".....", ".#...", "#.#.#", "...#.", ".....", "....."},
+	'|':  {"..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'[':  {".###.", ".#...", ".#...", ".#...", ".#...", ".#...", ".###."},
+	']':  {".###.", "...#.", "...#.", "...#.", "...#.", "...#.", ".###."},
+	'(':  {"...#.", "..#..", ".#...", ".#...", ".#...", "..#..", "...#."},
+	')':  {".#...", "..#..", "...#.", "...#.", "...#.", "..#..", ".#..."},
+	'*':  {".....", "..#..", "#.#.#", ".###.", "#.#.#", "..#..", "....."},
+	'%':  {"##...", "##..#", "...#.", "..#..", ".#...", "#..##", "...##"},
+	'"':  {".#.#.", ".#.#.", ".#.#.", ".....", ".....", ".....", "....."},
+	'\'': {"..#..", "..#..", "..#..", ".....", ".....", ".....", "....."},
+	'_':  {".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'@':  {".###.", "#...#", "#.###", "#.#.#", "#.###", "#....", ".###."},
+	'&':  {".##..", "#..#.", "#.#..", ".#...", "#.#.#", "#..#.", ".##.#"},
+	'$':  {"..#..", ".####", "#.#..", ".###.", "..#.#", "####.", "..#.."},
+	'^':  {"..#..", ".#.#.", "#...#", ".....", ".....", ".....", "....."},
+}
+
+// glyph returns the bitmap rows for a rune
+func glyph(r rune) [glyphHeight]string {
+	if g, ok := fontGlyphs[unicode.ToUpper(r)]; ok {
+		return g
+	}
+	return fontGlyphs['?']
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+// parsedAt pins "now" so current streaks and recency don't drift
+var parsedAt = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func TestMain(m *testing.M) {
+	// plain text output, whatever terminal runs the tests
+	lipgloss.SetColorProfile(termenv.Ascii)
+	os.Exit(m.Run())
+}
+
+// analyzeSample parses and analyzes a history from testdata
+func analyzeSample(t *testing.T, name, shell string) (*analyzer.Stats, *analyzer.Archetype) {
+	t.Helper()
+	return analyzeSampleWith(t, name, shell, analyzer.DefaultOptions())
+}
+
+// analyzeSampleWith is analyzeSample with custom options
+func analyzeSampleWith(t *testing.T, name, shell string, opts analyzer.Options) (*analyzer.Stats, *analyzer.Archetype) {
+	t.Helper()
+	data, err := parser.Parse(filepath.Join("testdata", name), shell)
+	if err != nil {
+		t.Fatalf("parsing %s: %v", name, err)
+	}
+	data.SetLocation(time.UTC)
+	data.Location = time.UTC
+	data.ParsedAt = parsedAt
+
+	opts.NameInUse = func(name string) bool { return samplePath[name] }
+	stats := analyzer.AnalyzeWithOptions(data, opts)
+	return stats, analyzer.DetectArchetype(stats)
+}
+
+// samplePath stands in for the commands on PATH, so suggested alias names
+// don't depend on the machine running the tests
+var samplePath = map[string]bool{
+	"bc": true, "cc": true, "dd": true, "df": true, "du": true, "ed": true, "gs": true,
+	"id": true, "ls": true, "ps": true, "sh": true, "tr": true, "vi": true, "wc": true,
+}
+
+// outputs renders every output format for a sample
+func outputs(t *testing.T, stats *analyzer.Stats, arch *analyzer.Archetype) map[string][]byte {
+	t.Helper()
+	must := func(s string, err error) []byte {
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []byte(s)
+	}
+
+	var png bytes.Buffer
+	if err := RenderCardPNG(&png, stats, arch); err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string][]byte{
+		"txt":           []byte(Render(stats, arch)),
+		"html":          must(RenderHTML(stats, arch)),
+		"svg":           []byte(RenderCardSVG(stats, arch)),
+		"png":           png.Bytes(),
+		"dot":           []byte(RenderDOT(stats)),
+		"zsh":           must(RenderAliasSnippet(stats, "zsh")),
+		"fish":          must(RenderAliasSnippet(stats, "fish")),
+		"bash":          must(RenderAliasSnippet(stats, "bash")),
+		"audit.txt":     must(RenderAudit(stats, "text")),
+		"audit.json":    must(RenderAudit(stats, "json")),
+		"packages.csv":  must(RenderPackageLedger(stats, "csv")),
+		"packages.json": must(RenderPackageLedger(stats, "json")),
+	}
+}
+
+func TestGolden(t *testing.T) {
+	samples := []struct {
+		file  string
+		shell string
+	}{
+		{"zsh_history", "zsh"},
+		{"bash_history", "bash"},
+	}
+
+	for _, sample := range samples {
+		t.Run(sample.file, func(t *testing.T) {
+			stats, arch := analyzeSample(t, sample.file, sample.shell)
+			for format, got := range outputs(t, stats, arch) {
+				golden := filepath.Join("testdata", "golden", sample.file+"."+format)
+				if *update {
+					if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(golden, got, 0644); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("%v (run go test ./internal/ui -update to create it)", err)
+				}
+				if !bytes.Equal(got, want) {
+					t.Errorf("%s output differs from %s (run go test ./internal/ui -update if the change is intended)", format, golden)
+				}
+			}
+		})
+	}
+}
+
+// with -hash-hosts no output may name a host the sample connects to
+func TestHashHostsHidesHostnames(t *testing.T) {
+	hosts := []string{"build.example.com"}
+	for _, sample := range []struct{ file, shell string }{{"zsh_history", "zsh"}, {"bash_history", "bash"}} {
+		t.Run(sample.file, func(t *testing.T) {
+			stats, arch := analyzeSample(t, sample.file, sample.shell)
+			plain := outputs(t, stats, arch)
+
+			opts := analyzer.DefaultOptions()
+			opts.HashHosts = true
+			stats, arch = analyzeSampleWith(t, sample.file, sample.shell, opts)
+			for format, got := range outputs(t, stats, arch) {
+				for _, host := range hosts {
+					if bytes.Contains(got, []byte(host)) {
+						t.Errorf("%s output names %s with HashHosts set", format, host)
+					}
+				}
+			}
+			// the sample has to show the host somewhere, or the check proves nothing
+			if !bytes.Contains(plain["txt"], []byte(hosts[0])) && !bytes.Contains(plain["zsh"], []byte(hosts[0])) {
+				t.Errorf("%s never shows %s without HashHosts", sample.file, hosts[0])
+			}
+		})
+	}
+}
+
+// map iteration order changes between runs, so analyzing the same history
+// repeatedly must not change anything that gets rendered
+func TestDeterministic(t *testing.T) {
+	stats, arch := analyzeSample(t, "zsh_history", "zsh")
+	first := outputs(t, stats, arch)
+	for i := 0; i < 10; i++ {
+		stats, arch := analyzeSample(t, "zsh_history", "zsh")
+		for format, got := range outputs(t, stats, arch) {
+			if !bytes.Equal(got, first[format]) {
+				t.Fatalf("run %d: %s output changed between runs", i+2, format)
+			}
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package ui
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"math"
+	"sort"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+// htmlCell is a single hour cell of the activity heatmap
+type htmlCell struct {
+	X, Y  int
+	Color string
+	Label string
+}
+
+// htmlSlice is one arc of the category donut
+type htmlSlice struct {
+	Name      string
+	Pct       float64
+	Color     string
+	DashArray string
+	Offset    string
+}
+
+// htmlRow is one row of the top commands table
+type htmlRow struct {
+	Rank    int
+	Command string
+	Count   int
+	Pct     float64
+}
+
+// htmlFact is a label/value pair shown in the insights grid
+type htmlFact struct {
+	Label string
+	Value string
+}
+
+type htmlReport struct {
+	Archetype *analyzer.Archetype
+	Total     string
+	Span      string
+	PerDay    string
+	Peak      string
+	Cells     []htmlCell
+	DayLabels []htmlCell
+	Slices    []htmlSlice
+	Rows      []htmlRow
+	Facts     []htmlFact
+	Note      string
+	Palette   map[string]string
+}
+
+// renderHTML produces a self-contained HTML report (inline CSS, SVG and JS, no external assets)
+func RenderHTML(stats *analyzer.Stats, archetype *analyzer.Archetype) (string, error) {
+	report := htmlReport{
+		Archetype: archetype,
+		Total:     FormatNumber(stats.TotalCommands),
+		Palette: map[string]string{
+			"primary":   string(ColorPrimary),
+			"secondary": string(ColorSecondary),
+			"accent":    string(ColorAccent),
+			"purple":    string(ColorPurple),
+			"dim":       string(ColorDim),
+			"muted":     string(ColorMuted),
+			"bright":    string(ColorBright),
+		},
+	}
+
+	if stats.HasTimeData && !stats.FirstCommand.IsZero() {
+		report.Span = fmt.Sprintf("%s -> %s (%s)",
+			stats.FirstCommand.Format("Jan 2006"),
+			stats.LastCommand.Format("Jan 2006"),
+			analyzer.FormatDuration(stats.HistorySpan))
+		report.PerDay = fmt.Sprintf("~%.0f commands/day", stats.CommandsPerDay)
+		report.Peak = fmt.Sprintf("%s %02d:00", analyzer.GetDayName(stats.PeakDay), stats.PeakHour)
+		report.Cells = htmlHeatmapCells(stats.HeatMap)
+		for day := 0; day < 7; day++ {
+			report.DayLabels = append(report.DayLabels, htmlCell{X: 0, Y: 32 + day*18, Label: analyzer.GetDayName(day)})
+		}
+	}
+
+	report.Slices = htmlDonutSlices(stats)
+
+	for i, cmd := range stats.TopCommands {
+		report.Rows = append(report.Rows, htmlRow{
+			Rank:    i + 1,
+			Command: cmd.Command,
+			Count:   cmd.Count,
+			Pct:     float64(cmd.Count) / float64(max(stats.TotalCommands, 1)) * 100,
+		})
+	}
+
+	report.Facts = htmlFacts(stats)
+	if stats.Approximate {
+		report.Note = approximateNote
+	}
+
+	var buf bytes.Buffer
+	if err := htmlTemplate.Execute(&buf, report); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+func htmlHeatmapCells(data [7][24]int) []htmlCell {
+	maxVal := 1
+	for day := 0; day < 7; day++ {
+		for hour := 0; hour < 24; hour++ {
+			if data[day][hour] > maxVal {
+				maxVal = data[day][hour]
+			}
+		}
+	}
+
+	cells := make([]htmlCell, 0, 7*24)
+	for day := 0; day < 7; day++ {
+		for hour := 0; hour < 24; hour++ {
+			count := data[day][hour]
+			cells = append(cells, htmlCell{
+				X:     40 + hour*18,
+				Y:     20 + day*18,
+				Color: string(HeatmapColors[heatmapColorIndex(count, maxVal)]),
+				Label: fmt.Sprintf("%s %02d:00 - %s commands", analyzer.GetDayName(day), hour, FormatNumber(count)),
+			})
+		}
+	}
+	return cells
+}
+
+func htmlDonutSlices(stats *analyzer.Stats) []htmlSlice {
+	type catItem struct {
+		name string
+		pct  float64
+	}
+	var cats []catItem
+	for name, pct := range stats.CategoryPct {
+		cats = append(cats, catItem{name, pct})
+	}
+	sort.Slice(cats, func(i, j int) bool {
+		if cats[i].pct != cats[j].pct {
+			return cats[i].pct > cats[j].pct
+		}
+		return cats[i].name < cats[j].name
+	})
+
+	// donut is drawn with stroke-dasharray on a circle of radius 60
+	circumference := 2 * math.Pi * 60
+	var slices []htmlSlice
+	offset := 0.0
+	for _, cat := range cats {
+		color := string(CategoryColors[cat.name])
+		if color == "" {
+			color = string(ColorMuted)
+		}
+		length := cat.pct / 100 * circumference
+		slices = append(slices, htmlSlice{
+			Name:      cat.name,
+			Pct:       cat.pct,
+			Color:     color,
+			DashArray: fmt.Sprintf("%.2f %.2f", length, circumference-length),
+			Offset:    fmt.Sprintf("%.2f", -offset),
+		})
+		offset += length
+	}
+	return slices
+}
+
+func htmlFacts(stats *analyzer.Stats) []htmlFact {
+	facts := []htmlFact{
+		{"Unique Commands", FormatNumber(stats.UniqueCommands)},
+		{"Longest Streak", fmt.Sprintf("%d days", stats.LongestStreak)},
+		{"Busiest Day", formatBusiestDay(stats)},
+		{"sudo", fmt.Sprintf("%s (%s)", FormatNumber(stats.SudoCount), analyzer.GetSudoLevel(stats.SudoPct))},
+	}
+
+	if stats.HasTimeData {
+		facts = append(facts, htmlFact{"Night Owl", fmt.Sprintf("%.0f%% after midnight", stats.NightOwlPct)})
+		facts = append(facts, htmlFact{"Weekend", fmt.Sprintf("%.0f%% on Sat/Sun", stats.WeekendPct)})
+	}
+	if stats.FavoriteDir != "" {
+		facts = append(facts, htmlFact{"Home Dir", stats.FavoriteDir})
+	}
+	if stats.EditorChoice != "" {
+		facts = append(facts, htmlFact{"Editor", fmt.Sprintf("%s (%s)", stats.EditorChoice, FormatNumber(stats.EditorCount))})
+	}
+	if len(stats.EditorSplit) > 1 {
+		facts = append(facts, htmlFact{"Editor Wars", formatEditorWars(stats.EditorSplit)})
+	}
+	if len(stats.TopEditedFiles) > 0 {
+		facts = append(facts, htmlFact{"Hot Files", formatCounts(stats.TopEditedFiles, 3)})
+	}
+	if len(stats.TopEditedExtensions) > 0 {
+		facts = append(facts, htmlFact{"File Types", formatCounts(stats.TopEditedExtensions, 3)})
+	}
+	if len(stats.TopConfigFiles) > 0 {
+		facts = append(facts, htmlFact{"Config Files", formatCounts(stats.TopConfigFiles, 3)})
+	}
+	facts = append(facts, htmlFact{"Avg Length", fmt.Sprintf("%.0f chars", stats.AvgCommandLen)})
+	if stats.PipeCount > 0 {
+		facts = append(facts, htmlFact{"Pipe Depth", fmt.Sprintf("%.1f avg, %d max", stats.AvgPipeDepth, stats.MaxPipeDepth)})
+		if len(stats.PipeTargets) > 0 {
+			facts = append(facts, htmlFact{"Piped Into", formatCounts(stats.PipeTargets, 3)})
+		}
+	}
+	return facts
+}
+
+var htmlTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Terminal Wrapped</title>
+<style>
+  :root {
+    --primary: {{index .Palette "primary"}};
+    --secondary: {{index .Palette "secondary"}};
+    --accent: {{index .Palette "accent"}};
+    --purple: {{index .Palette "purple"}};
+    --dim: {{index .Palette "dim"}};
+    --muted: {{index .Palette "muted"}};
+    --bright: {{index .Palette "bright"}};
+  }
+  * { box-sizing: border-box; }
+  body { margin: 0; padding: 32px 16px; background: #111318; color: var(--bright);
+         font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; }
+  main { max-width: 860px; margin: 0 auto; }
+  h1 { margin: 0 0 24px; text-align: center; font-size: 32px; letter-spacing: 2px;
+       background: linear-gradient(90deg, var(--primary), var(--accent), var(--secondary));
+       -webkit-background-clip: text; background-clip: text; color: transparent; }
+  h2 { margin: 0 0 12px; font-size: 13px; color: var(--secondary); text-transform: uppercase; letter-spacing: 1px; }
+  .grid { display: grid; grid-template-columns: 1fr 1fr; gap: 16px; margin-bottom: 16px; }
+  .card { border: 1px solid var(--dim); border-radius: 12px; padding: 16px 20px; background: #171a21; }
+  .hero { border-color: var(--primary); }
+  .arch { border-color: var(--purple); }
+  .label { color: var(--muted); font-size: 13px; }
+  .big { font-size: 44px; font-weight: bold; color: var(--accent); margin: 8px 0; }
+  .arch-name { font-size: 22px; font-weight: bold; margin: 12px 0 8px; }
+  .arch-icon { color: var(--accent); margin-right: 8px; }
+  .tagline { color: var(--muted); font-style: italic; }
+  table { width: 100%; border-collapse: collapse; font-size: 14px; }
+  th { text-align: left; color: var(--muted); font-weight: normal; cursor: pointer; user-select: none;
+       border-bottom: 1px solid var(--dim); padding: 6px 4px; }
+  th:hover { color: var(--accent); }
+  td { padding: 6px 4px; border-bottom: 1px solid #23262e; }
+  td.num { text-align: right; }
+  .legend { list-style: none; padding: 0; margin: 0; font-size: 13px; }
+  .legend li { margin: 4px 0; }
+  .swatch { display: inline-block; width: 10px; height: 10px; border-radius: 2px; margin-right: 6px; }
+  .facts { display: grid; grid-template-columns: 1fr 1fr; gap: 8px 24px; font-size: 14px; }
+  .facts b { color: var(--bright); }
+  #tooltip { position: fixed; pointer-events: none; display: none; padding: 4px 8px; border-radius: 4px;
+             background: #000; border: 1px solid var(--dim); font-size: 12px; }
+  footer { text-align: center; color: var(--dim); font-size: 12px; margin-top: 24px; }
+  footer span { color: var(--accent); }
+  @media (max-width: 700px) { .grid, .facts { grid-template-columns: 1fr; } }
+</style>
+</head>
+<body>
+<main>
+  <h1>TERMINAL WRAPPED</h1>
+
+  <div class="grid">
+    <section class="card hero">
+      <div class="label">TOTAL COMMANDS</div>
+      <div class="big">{{.Total}}</div>
+      {{if .Span}}
+      <div class="label">{{.Span}}</div>
+      <div class="label">{{.PerDay}}</div>
+      {{else}}
+      <div class="label">All-time history (no timestamps)</div>
+      {{end}}
+    </section>
+    <section class="card arch">
+      <div class="label">YOUR ARCHETYPE</div>
+      <div class="arch-name"><span class="arch-icon">{{.Archetype.Icon}}</span>{{.Archetype.Name}}</div>
+      <div class="tagline">"{{.Archetype.Tagline}}"</div>
+    </section>
+  </div>
+
+  <div class="grid">
+    <section class="card">
+      <h2>Top Commands</h2>
+      <table id="top-commands">
+        <thead>
+          <tr><th data-type="num">#</th><th data-type="text">Command</th><th data-type="num">Count</th><th data-type="num">Share</th></tr>
+        </thead>
+        <tbody>
+          {{range .Rows}}
+          <tr>
+            <td class="num">{{.Rank}}</td>
+            <td>{{.Command}}</td>
+            <td class="num">{{.Count}}</td>
+            <td class="num" data-value="{{printf "%.2f" .Pct}}">{{printf "%.1f" .Pct}}%</td>
+          </tr>
+          {{end}}
+        </tbody>
+      </table>
+    </section>
+    <section class="card">
+      <h2>Categories</h2>
+      {{if .Slices}}
+      <svg viewBox="0 0 160 160" width="160" height="160" role="img" aria-label="Category breakdown">
+        <circle cx="80" cy="80" r="60" fill="none" stroke="#23262e" stroke-width="24"/>
+        {{range .Slices}}
+        <circle cx="80" cy="80" r="60" fill="none" stroke="{{.Color}}" stroke-width="24"
+                stroke-dasharray="{{.DashArray}}" stroke-dashoffset="{{.Offset}}" transform="rotate(-90 80 80)">
+          <title>{{.Name}}: {{printf "%.1f" .Pct}}%</title>
+        </circle>
+        {{end}}
+      </svg>
+      <ul class="legend">
+        {{range .Slices}}
+        <li><span class="swatch" style="background: {{.Color}}"></span>{{.Name}} {{printf "%.1f" .Pct}}%</li>
+        {{end}}
+      </ul>
+      {{else}}
+      <div class="label">No categorized commands</div>
+      {{end}}
+    </section>
+  </div>
+
+  <section class="card" style="margin-bottom: 16px">
+    <h2>Activity</h2>
+    {{if .Cells}}
+    <svg id="heatmap" viewBox="0 0 480 150" width="100%" role="img" aria-label="Activity heatmap">
+      {{range .DayLabels}}
+      <text x="{{.X}}" y="{{.Y}}" fill="{{index $.Palette "dim"}}" font-size="10">{{.Label}}</text>
+      {{end}}
+      {{range .Cells}}
+      <rect x="{{.X}}" y="{{.Y}}" width="16" height="16" rx="3" fill="{{.Color}}" data-label="{{.Label}}"><title>{{.Label}}</title></rect>
+      {{end}}
+    </svg>
+    <div class="label">Peak: {{.Peak}}</div>
+    {{else}}
+    <div class="label">No timestamp data - enable EXTENDED_HISTORY</div>
+    {{end}}
+  </section>
+
+  <section class="card">
+    <h2>Insights</h2>
+    <div class="facts">
+      {{range .Facts}}
+      <div><span class="label">{{.Label}}:</span> <b>{{.Value}}</b></div>
+      {{end}}
+    </div>
+  </section>
+
+  {{if .Note}}<div class="label">{{.Note}}</div>
+  {{end}}<footer>github.com/Anish-Reddy-K/terminal-wrapped &middot; Share with <span>#TerminalWrapped</span></footer>
+</main>
+<div id="tooltip"></div>
+<script>
+(function () {
+  // heatmap tooltips
+  var tip = document.getElementById("tooltip");
+  document.querySelectorAll("#heatmap rect").forEach(function (rect) {
+    rect.addEventListener("mousemove", function (e) {
+      tip.textContent = rect.dataset.label;
+      tip.style.display = "block";
+      tip.style.left = (e.clientX + 12) + "px";
+      tip.style.top = (e.clientY + 12) + "px";
+    });
+    rect.addEventListener("mouseleave", function () { tip.style.display = "none"; });
+  });
+
+  // sortable top commands table
+  var table = document.getElementById("top-commands");
+  if (!table) return;
+  table.querySelectorAll("th").forEach(function (th, col) {
+    var asc = false;
+    th.addEventListener("click", function () {
+      asc = !asc;
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[col].dataset.value || a.cells[col].textContent;
+        var y = b.cells[col].dataset.value || b.cells[col].textContent;
+        var cmp = th.dataset.type === "num" ? parseFloat(x) - parseFloat(y) : x.localeCompare(y);
+        return asc ? cmp : -cmp;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,79 @@
+package ui
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+// packageReport is the JSON shape of the package ledger
+type packageReport struct {
+	Installs     int                     `json:"installs"`
+	Removes      int                     `json:"removes"`
+	Ecosystems   []packageEcosystem      `json:"ecosystems"`
+	TopInstalled []packageTop            `json:"top_installed"`
+	Ledger       []analyzer.PackageEvent `json:"ledger"`
+}
+
+type packageEcosystem struct {
+	Ecosystem string `json:"ecosystem"`
+	Installs  int    `json:"installs"`
+	Removes   int    `json:"removes"`
+}
+
+type packageTop struct {
+	Package   string `json:"package"`
+	Ecosystem string `json:"ecosystem"`
+	Count     int    `json:"count"`
+}
+
+// renderPackageLedger exports the package install/remove ledger as "csv" or "json"
+func RenderPackageLedger(stats *analyzer.Stats, format string) (string, error) {
+	p := stats.Packages
+	switch format {
+	case "json":
+		report := packageReport{
+			Installs:     p.Installs,
+			Removes:      p.Removes,
+			Ecosystems:   []packageEcosystem{},
+			TopInstalled: []packageTop{},
+			Ledger:       p.Ledger,
+		}
+		for _, e := range p.Ecosystems {
+			report.Ecosystems = append(report.Ecosystems, packageEcosystem{e.Ecosystem, e.Installs, e.Removes})
+		}
+		for _, t := range p.TopInstalled {
+			report.TopInstalled = append(report.TopInstalled, packageTop{t.Package, t.Ecosystem, t.Count})
+		}
+		if report.Ledger == nil {
+			report.Ledger = []analyzer.PackageEvent{}
+		}
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(out) + "\n", nil
+	case "csv":
+		var sb strings.Builder
+		w := csv.NewWriter(&sb)
+		w.Write([]string{"time", "action", "ecosystem", "manager", "package"})
+		for _, e := range p.Ledger {
+			ts := ""
+			if !e.Time.IsZero() {
+				ts = e.Time.Format(time.RFC3339)
+			}
+			w.Write([]string{ts, e.Action, e.Ecosystem, e.Manager, e.Package})
+		}
+		w.Flush()
+		if err := w.Error(); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+	default:
+		return "", fmt.Errorf("unsupported package ledger format %q (use csv or json)", format)
+	}
+}
@@ -2,8 +2,10 @@ package ui
 
 import (
 	"fmt"
+	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 
@@ -18,6 +20,9 @@ const (
 	ColGap        = 2
 )
 
+// shown when the analyzer had to estimate rare counts to bound its memory
+const approximateNote = "~ long-tail counts are estimates: too many distinct lines to count them all"
+
 // render produces the complete terminal output
 func Render(stats *analyzer.Stats, archetype *analyzer.Archetype) string {
 	var sb strings.Builder
@@ -47,10 +52,105 @@ func Render(stats *analyzer.Stats, archetype *analyzer.Archetype) string {
 	sb.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, topCmds, "  ", rightPanel))
 	sb.WriteString("\n\n")
 
+	// sessions
+	if stats.SessionCount > 0 {
+		sb.WriteString(renderSessions(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// streaks
+	if stats.ActiveDays > 0 {
+		sb.WriteString(renderStreaks(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// monthly and weekly trends
+	if len(stats.MonthlyTrend) > 1 {
+		sb.WriteString(renderTrends(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// tool adoption
+	if len(stats.AdoptionTimeline) > 0 || len(stats.AbandonedTools) > 0 {
+		sb.WriteString(renderToolTimeline(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// projects
+	if len(stats.TopProjects) > 0 {
+		sb.WriteString(renderProjects(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// languages
+	if len(stats.Languages) > 0 {
+		sb.WriteString(renderLanguages(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// workflows
+	if len(stats.TopWorkflows) > 0 {
+		sb.WriteString(renderWorkflows(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// git deep dive, once there's enough git to talk about
+	if stats.Git.Commands >= minGitCommands {
+		sb.WriteString(renderGit(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// kubernetes, terraform and cloud
+	if stats.Infra.KubeCommands+stats.Infra.CloudCommands >= minInfraCommands {
+		sb.WriteString(renderInfra(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// ssh and file transfers
+	if stats.SSH.Sessions+stats.SSH.Transfers > 0 {
+		sb.WriteString(renderRemote(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// package ledger
+	if stats.Packages.Installs+stats.Packages.Removes > 0 {
+		sb.WriteString(renderPackages(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// flags
+	if len(stats.TopFlags) > 0 {
+		sb.WriteString(renderFlags(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// pipelines and one-liners
+	if len(stats.HallOfFame) > 0 {
+		sb.WriteString(renderHallOfFame(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// alias suggestions
+	if len(stats.AliasSuggestions) > 0 {
+		sb.WriteString(renderAliasSuggestions(stats))
+		sb.WriteString("\n\n")
+	}
+
+	// dangerous commands
+	if len(stats.RiskFindings) > 0 {
+		sb.WriteString(renderRiskyBusiness(stats))
+		sb.WriteString("\n\n")
+	}
+
 	// fun facts row
 	sb.WriteString(renderFunFacts(stats))
 	sb.WriteString("\n\n")
 
+	if stats.Approximate {
+		sb.WriteString(SubtleStyle.Render(CenterText(approximateNote, TotalWidth)))
+		sb.WriteString("\n\n")
+	}
+
 	// history tip
 	sb.WriteString(RenderHistoryTip())
 	sb.WriteString("\n\n")
@@ -299,7 +399,10 @@ func renderCategoryMix(stats *analyzer.Stats) string {
 		cats = append(cats, catItem{name, pct})
 	}
 	sort.Slice(cats, func(i, j int) bool {
-		return cats[i].pct > cats[j].pct
+		if cats[i].pct != cats[j].pct {
+			return cats[i].pct > cats[j].pct
+		}
+		return cats[i].name < cats[j].name
 	})
 
 	// display in 2 columns, up to 8 categories
@@ -398,11 +501,52 @@ func renderFunFacts(stats *analyzer.Stats) string {
 	if stats.EditorChoice != "" {
 		facts = append(facts, fact{":w", "Editor", fmt.Sprintf("%s (%s)", stats.EditorChoice, FormatNumber(stats.EditorCount))})
 	}
+	if len(stats.EditorSplit) > 1 {
+		facts = append(facts, fact{"vs", "Editor War", TruncateString(formatEditorWars(stats.EditorSplit), 20)})
+	}
+	if len(stats.TopEditedFiles) > 0 {
+		top := stats.TopEditedFiles[0]
+		facts = append(facts, fact{"[]", "Hot File", TruncateString(fmt.Sprintf("%s x%s", path.Base(top.Command), FormatNumber(top.Count)), 20)})
+	}
+	if len(stats.TopEditedExtensions) > 0 {
+		facts = append(facts, fact{"*.", "File Types", TruncateString(formatCounts(stats.TopEditedExtensions, 2), 20)})
+	}
+	if len(stats.TopConfigFiles) > 0 {
+		facts = append(facts, fact{"rc", "Config", TruncateString(formatCounts(stats.TopConfigFiles, 2), 20)})
+	}
 
 	facts = append(facts, fact{"##", "Avg Length", fmt.Sprintf("%.0f chars", stats.AvgCommandLen)})
 
 	if stats.PipeCount > 0 {
-		facts = append(facts, fact{"|>", "Complexity", fmt.Sprintf("%.1f%% use pipes", stats.PipePct)})
+		facts = append(facts, fact{"|>", "Pipe Depth", fmt.Sprintf("%.1f avg, %d max", stats.AvgPipeDepth, stats.MaxPipeDepth)})
+	}
+
+	if len(stats.AliasUsage) > 0 {
+		top := stats.AliasUsage[0]
+		facts = append(facts, fact{"@=", "Aliases", TruncateString(fmt.Sprintf("%.0f%% typed, %s x%s", stats.AliasedPct, top.Command, FormatNumber(top.Count)), 20)})
+	}
+
+	if len(stats.TopTypos) > 0 {
+		top := stats.TopTypos[0]
+		facts = append(facts, fact{"#$", "Fat Finger", TruncateString(fmt.Sprintf("%s->%s x%s", top.Typo, top.Intended, FormatNumber(top.Count)), 20)})
+		facts = append(facts, fact{"?!", "Typo Rate", fmt.Sprintf("%.1f%% of commands", stats.TypoPct)})
+	}
+
+	if stats.FlagCount > 0 {
+		facts = append(facts, fact{"-f", "Flags", fmt.Sprintf("%s (%.1f/cmd)", analyzer.GetFlagLevel(stats.FlagsPerCommand), stats.FlagsPerCommand)})
+	}
+
+	if len(stats.Languages) > 0 {
+		facts = append(facts, fact{"</", "Languages", fmt.Sprintf("%s (%.1f)", analyzer.GetPolyglotLevel(stats.PolyglotScore), stats.PolyglotScore)})
+	}
+
+	if len(stats.Discoveries) > 0 {
+		top := stats.Discoveries[0]
+		facts = append(facts, fact{"**", "Discovery", TruncateString(fmt.Sprintf("%s since %s", top.Command, top.FirstSeen.Format("Jan")), 20)})
+	}
+
+	if len(stats.SignatureCombo.Steps) > 0 {
+		facts = append(facts, fact{"=>", "Signature", TruncateString(FormatWorkflow(stats.SignatureCombo.Steps), 20)})
 	}
 
 	// render in 2 columns
@@ -428,3 +572,734 @@ func renderFunFacts(stats *analyzer.Stats) string {
 
 	return style.Render(strings.Join(lines, "\n"))
 }
+
+func renderSessions(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	colWidth := 14
+	items := []struct {
+		label string
+		value string
+	}{
+		{"Sessions", FormatNumber(stats.SessionCount)},
+		{"Per Day", fmt.Sprintf("%.1f", stats.SessionsPerDay)},
+		{"Avg Length", formatShortDuration(stats.AvgSessionLength)},
+		{"Longest", formatShortDuration(stats.LongestSession)},
+		{"Cmds/Session", fmt.Sprintf("%.1f", stats.CommandsPerSession)},
+	}
+
+	header := headerStyle.Render("-- SESSIONS ") + SubtleStyle.Render(strings.Repeat("-", 60))
+
+	var labelRow strings.Builder
+	var valueRow strings.Builder
+	for _, item := range items {
+		labelRow.WriteString(padRight(LabelStyle.Render(item.label), colWidth))
+		valueRow.WriteString(padRight(ValueStyle.Render(item.value), colWidth))
+	}
+
+	rhythm := fmt.Sprintf(">> Usually start ~%02d:00, wrap up ~%02d:00", stats.TypicalSessionStart, stats.TypicalSessionEnd)
+	if !stats.LongestSessionStart.IsZero() {
+		rhythm += SubtleStyle.Render(fmt.Sprintf("  (marathon: %s)", stats.LongestSessionStart.Format("Jan 2")))
+	}
+
+	content := header + "\n" + labelRow.String() + "\n" + valueRow.String() + "\n" + AccentStyle.Render(rhythm)
+	return style.Render(content)
+}
+
+func renderRiskyBusiness(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	highStyle := lipgloss.NewStyle().Foreground(ColorPrimary).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- RISKY BUSINESS ")+SubtleStyle.Render(strings.Repeat("-", 55)))
+
+	// show top 5
+	for i, f := range stats.RiskFindings {
+		if i >= 5 {
+			break
+		}
+		marker := SubtleStyle.Render(" ! ")
+		if f.Severity == "high" {
+			marker = highStyle.Render("!! ")
+		}
+		rule := ValueStyle.Render(padRight(f.Rule, 20))
+		count := LabelStyle.Render(fmt.Sprintf("%6s", "x"+FormatNumber(f.Count)))
+		when := ""
+		if !f.Last.IsZero() {
+			when = SubtleStyle.Render("  last " + f.Last.Format("Jan 2, 2006"))
+		}
+		lines = append(lines, marker+rule+count+when)
+	}
+	lines = append(lines, AccentStyle.Render(fmt.Sprintf(">> %.1f%% of commands made the list", stats.RiskyPct))+
+		SubtleStyle.Render("  (details: -audit text)"))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// formatShortDuration formats session lengths like "1h 25m"
+func formatShortDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	switch {
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+func renderStreaks(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	current := "-"
+	if stats.CurrentStreak > 0 {
+		current = FormatPlural(stats.CurrentStreak, "day")
+	}
+	items := []struct {
+		label string
+		value string
+	}{
+		{"Current", current},
+		{"Longest", FormatPlural(stats.LongestStreak, "day")},
+		{"Active Days", FormatNumber(stats.ActiveDays)},
+		{"Streaks", FormatNumber(len(stats.Streaks))},
+		{"Longest Break", FormatPlural(stats.LongestBreak, "day")},
+	}
+
+	var left []string
+	for _, item := range items {
+		left = append(left, padRight(LabelStyle.Render(item.label), 15)+ValueStyle.Render(item.value))
+	}
+
+	record := AccentStyle.Render(">> Record streak: " + formatDateSpan(stats.LongestStreakStart, stats.LongestStreakEnd))
+	if stats.LongestBreak > 0 {
+		record += SubtleStyle.Render("  (longest break: " + formatDateSpan(stats.LongestBreakStart, stats.LongestBreakEnd) + ")")
+	}
+
+	header := headerStyle.Render("-- STREAKS ") + SubtleStyle.Render(strings.Repeat("-", 62))
+	body := lipgloss.JoinHorizontal(lipgloss.Top, lipgloss.NewStyle().Width(32).Render(strings.Join(left, "\n")), streakCalendar(stats, 38))
+	return style.Render(header + "\n" + body + "\n" + record)
+}
+
+// streakCalendar draws the last weeks of activity, one column per week and
+// one row per weekday, with the record streak highlighted
+func streakCalendar(stats *analyzer.Stats, weeks int) string {
+	last := stats.Streaks[len(stats.Streaks)-1].End
+	// first column starts on a Sunday
+	start := last.AddDate(0, 0, -int(last.Weekday())-7*(weeks-1))
+
+	active := make(map[time.Time]bool)
+	record := make(map[time.Time]bool)
+	for _, streak := range stats.Streaks {
+		if streak.End.Before(start) {
+			continue
+		}
+		for day := streak.Start; !day.After(streak.End); day = day.AddDate(0, 0, 1) {
+			active[day] = true
+			if streak.Start.Equal(stats.LongestStreakStart) {
+				record[day] = true
+			}
+		}
+	}
+
+	activeStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+	recordStyle := lipgloss.NewStyle().Foreground(ColorAccent)
+
+	var rows []string
+	for weekday := 0; weekday < 7; weekday++ {
+		var row strings.Builder
+		row.WriteString(SubtleStyle.Render(analyzer.GetDayName(weekday)[:1] + " "))
+		for week := 0; week < weeks; week++ {
+			day := start.AddDate(0, 0, week*7+weekday)
+			switch {
+			case day.After(last):
+				row.WriteString(" ")
+			case record[day]:
+				row.WriteString(recordStyle.Render("■"))
+			case active[day]:
+				row.WriteString(activeStyle.Render("■"))
+			default:
+				row.WriteString(SubtleStyle.Render("·"))
+			}
+		}
+		rows = append(rows, row.String())
+	}
+	return strings.Join(rows, "\n")
+}
+
+// formatDateSpan formats a date range like "Mar 3 - Mar 21, 2025"
+func formatDateSpan(from, to time.Time) string {
+	switch {
+	case from.Equal(to):
+		return to.Format("Jan 2, 2006")
+	case from.Year() == to.Year():
+		return from.Format("Jan 2") + " - " + to.Format("Jan 2, 2006")
+	default:
+		return from.Format("Jan 2, 2006") + " - " + to.Format("Jan 2, 2006")
+	}
+}
+
+func renderTrends(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- TRENDS ")+SubtleStyle.Render(strings.Repeat("-", 63)))
+
+	// show the latest 12 months
+	months := stats.MonthlyTrend
+	if len(months) > 12 {
+		months = months[len(months)-12:]
+	}
+	maxCount := 0
+	for _, m := range months {
+		maxCount = max(maxCount, m.Count)
+	}
+
+	for _, m := range months {
+		label := m.Month
+		if month, err := time.Parse("2006-01", m.Month); err == nil {
+			label = month.Format("Jan 06")
+		}
+		bar := ProgressBar(m.Count, maxCount, 24, ColorSecondary)
+		count := LabelStyle.Render(fmt.Sprintf("%7s", FormatNumber(m.Count)))
+		top := ValueStyle.Render(padRight(TruncateString(m.TopCommand, 10), 10))
+		lines = append(lines, fmt.Sprintf("%s %s %s  %s %s", LabelStyle.Render(label), bar, count, top, categoryStrip(m.CategoryPct, 14)))
+	}
+
+	weekly := make([]int, len(stats.WeeklyCounts))
+	for i, w := range stats.WeeklyCounts {
+		weekly[i] = w.Count
+	}
+	if len(weekly) > 56 {
+		weekly = weekly[len(weekly)-56:]
+	}
+	lines = append(lines, "", LabelStyle.Render(padRight("Weekly", 7))+Sparkline(weekly, ColorAccent))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// categoryStrip draws category shares as one stacked bar, biggest first
+func categoryStrip(pct map[string]float64, width int) string {
+	type share struct {
+		name string
+		pct  float64
+	}
+	var shares []share
+	for name, p := range pct {
+		shares = append(shares, share{name, p})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].pct != shares[j].pct {
+			return shares[i].pct > shares[j].pct
+		}
+		return shares[i].name < shares[j].name
+	})
+
+	var sb strings.Builder
+	used := 0
+	for _, s := range shares {
+		cells := int(s.pct/100*float64(width) + 0.5)
+		cells = min(cells, width-used)
+		if cells <= 0 {
+			continue
+		}
+		color := CategoryColors[s.name]
+		if color == "" {
+			color = ColorMuted
+		}
+		sb.WriteString(lipgloss.NewStyle().Foreground(color).Render(strings.Repeat("▮", cells)))
+		used += cells
+	}
+	sb.WriteString(SubtleStyle.Render(strings.Repeat("·", width-used)))
+	return sb.String()
+}
+
+func renderToolTimeline(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	addedStyle := lipgloss.NewStyle().Foreground(ColorGreen)
+	droppedStyle := lipgloss.NewStyle().Foreground(ColorPrimary)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- TOOL TIMELINE ")+SubtleStyle.Render(strings.Repeat("-", 56)))
+
+	// show the latest 6 months with new tools
+	timeline := stats.AdoptionTimeline
+	if len(timeline) > 6 {
+		timeline = timeline[len(timeline)-6:]
+	}
+	for _, m := range timeline {
+		label := m.Month
+		if month, err := time.Parse("2006-01", m.Month); err == nil {
+			label = month.Format("Jan 2006")
+		}
+		tools := TruncateString(strings.Join(m.Tools, ", "), 58)
+		lines = append(lines, LabelStyle.Render(padRight(label, 10))+addedStyle.Render("+ ")+ValueStyle.Render(tools))
+	}
+
+	if len(stats.AbandonedTools) > 0 {
+		var dropped []string
+		for _, tool := range stats.AbandonedTools {
+			dropped = append(dropped, fmt.Sprintf("%s (%s)", tool.Command, tool.LastSeen.Format("Jan 2")))
+		}
+		lines = append(lines, LabelStyle.Render(padRight("Dropped", 10))+droppedStyle.Render("- ")+
+			SubtleStyle.Render(TruncateString(strings.Join(dropped, ", "), 58)))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderLanguages(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	lines := []string{headerStyle.Render("-- LANGUAGES ") + SubtleStyle.Render(strings.Repeat("-", 60))}
+
+	maxCount := stats.Languages[0].Commands
+	for _, language := range stats.Languages {
+		name := ValueStyle.Render(padRight(language.Language, 12))
+		bar := ProgressBar(language.Commands, maxCount, 36, ColorAccent)
+		pct := LabelStyle.Render(fmt.Sprintf("%5.1f%%", language.Pct))
+		count := SubtleStyle.Render(fmt.Sprintf("%8s cmds", FormatNumber(language.Commands)))
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", name, bar, pct, count))
+	}
+
+	lines = append(lines, AccentStyle.Render(fmt.Sprintf(">> Polyglot score %.1f: %s", stats.PolyglotScore, analyzer.GetPolyglotLevel(stats.PolyglotScore)))+
+		SubtleStyle.Render(fmt.Sprintf("  (%s in regular use)", FormatPlural(stats.LanguageCount, "language"))))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderProjects(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- PROJECTS ")+SubtleStyle.Render(strings.Repeat("-", 61)))
+
+	maxCount := stats.TopProjects[0].Commands
+	// show top 5
+	for i, p := range stats.TopProjects {
+		if i >= 5 {
+			break
+		}
+		num := SubtleStyle.Render(fmt.Sprintf("%d.", i+1))
+		name := ValueStyle.Render(padRight(TruncateString(p.Name, 22), 22))
+		bar := ProgressBar(p.Commands, maxCount, 24, ColorPrimary)
+		count := LabelStyle.Render(fmt.Sprintf("%6s cmds", FormatNumber(p.Commands)))
+		spent := ""
+		if p.Time > 0 {
+			spent = AccentStyle.Render(fmt.Sprintf("%8s", formatShortDuration(p.Time)))
+		}
+		lines = append(lines, fmt.Sprintf("%s %s %s %s %s", num, name, bar, count, spent))
+	}
+
+	summary := AccentStyle.Render(fmt.Sprintf(">> %s touched", FormatPlural(stats.ProjectCount, "project")))
+	if len(stats.ProjectsPerMonth) > 1 {
+		counts := make([]int, len(stats.ProjectsPerMonth))
+		busiest := stats.ProjectsPerMonth[0]
+		for i, m := range stats.ProjectsPerMonth {
+			counts[i] = m.Count
+			if m.Count > busiest.Count {
+				busiest = m
+			}
+		}
+		summary += AccentStyle.Render(", per month ") + Sparkline(counts, ColorSecondary)
+		if month, err := time.Parse("2006-01", busiest.Month); err == nil {
+			summary += SubtleStyle.Render(fmt.Sprintf("  (peak: %d in %s)", busiest.Count, month.Format("Jan 2006")))
+		}
+	}
+	lines = append(lines, summary)
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderWorkflows(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- WORKFLOWS ")+SubtleStyle.Render(strings.Repeat("-", 60)))
+
+	for i, wf := range stats.TopWorkflows {
+		num := SubtleStyle.Render(fmt.Sprintf("%d.", i+1))
+		flow := ValueStyle.Render(padRight(TruncateString(FormatWorkflow(wf.Steps), 60), 60))
+		count := LabelStyle.Render(fmt.Sprintf("%6s", "x"+FormatNumber(wf.Count)))
+		lines = append(lines, fmt.Sprintf("%s %s %s", num, flow, count))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// git commands needed before the git panel shows up
+const minGitCommands = 20
+
+func renderGit(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	git := stats.Git
+
+	header := headerStyle.Render("-- GIT ") + SubtleStyle.Render(strings.Repeat("-", 66))
+
+	colWidth := 14
+	items := []struct {
+		label string
+		value string
+	}{
+		{"Commits", FormatNumber(git.Commits)},
+		{"Amends", FormatNumber(git.Amends)},
+		{"Force Pushes", fmt.Sprintf("%s/%s", FormatNumber(git.ForcePushes), FormatNumber(git.Pushes))},
+		{"Stash/Pop", fmt.Sprintf("%s/%s", FormatNumber(git.Stashes), FormatNumber(git.StashPops))},
+		{"Status/Commit", fmt.Sprintf("%.1f", git.StatusRatio)},
+	}
+	var labelRow, valueRow strings.Builder
+	for _, item := range items {
+		labelRow.WriteString(padRight(LabelStyle.Render(item.label), colWidth))
+		valueRow.WriteString(padRight(ValueStyle.Render(item.value), colWidth))
+	}
+	lines := []string{header, labelRow.String(), valueRow.String(), ""}
+
+	mergeStyle := "balanced"
+	switch {
+	case git.Rebases > git.Merges*2:
+		mergeStyle = "rebaser"
+	case git.Merges > git.Rebases*2:
+		mergeStyle = "merger"
+	}
+	lines = append(lines, LabelStyle.Render(padRight("History", 10))+
+		ValueStyle.Render(fmt.Sprintf("%s rebases vs %s merges", FormatNumber(git.Rebases), FormatNumber(git.Merges)))+
+		SubtleStyle.Render(" ("+mergeStyle+")"))
+
+	if len(git.TopBranches) > 0 {
+		var branches []string
+		for _, b := range git.TopBranches {
+			branches = append(branches, fmt.Sprintf("%s x%s", b.Command, FormatNumber(b.Count)))
+		}
+		lines = append(lines, LabelStyle.Render(padRight("Branches", 10))+ValueStyle.Render(TruncateString(strings.Join(branches, "  "), 62)))
+	}
+
+	if git.MessageCount > 0 {
+		note := fmt.Sprintf("  shortest: %q", TruncateString(git.ShortestMessage, 16))
+		if git.LazyMessages > 0 {
+			note = fmt.Sprintf("  %s lazy ones like %q", FormatNumber(git.LazyMessages), TruncateString(git.ShortestMessage, 10))
+		}
+		lines = append(lines, LabelStyle.Render(padRight("Messages", 10))+
+			ValueStyle.Render(fmt.Sprintf("avg %.0f chars, median %d", git.AvgMessageLen, git.MedianMessageLen))+
+			SubtleStyle.Render(note))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// kube and cloud commands needed before the infra panel shows up
+const minInfraCommands = 20
+
+func renderInfra(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	infra := stats.Infra
+
+	lines := []string{headerStyle.Render("-- INFRA ") + SubtleStyle.Render(strings.Repeat("-", 64))}
+
+	row := func(label string, counts []analyzer.CommandCount) {
+		if len(counts) == 0 {
+			return
+		}
+		var items []string
+		for _, c := range counts {
+			items = append(items, fmt.Sprintf("%s x%s", c.Command, FormatNumber(c.Count)))
+		}
+		lines = append(lines, LabelStyle.Render(padRight(label, 12))+ValueStyle.Render(TruncateString(strings.Join(items, "  "), 60)))
+	}
+	row("Contexts", infra.TopContexts)
+	row("Namespaces", infra.TopNamespaces)
+	row("kubectl", infra.TopKubeVerbs)
+	row("Cloud", infra.CloudServices)
+
+	if runs := infra.TerraformPlans + infra.TerraformApplies + infra.TerraformDestroys; runs > 0 {
+		lines = append(lines, LabelStyle.Render(padRight("Terraform", 12))+
+			ValueStyle.Render(fmt.Sprintf("%s plans / %s applies / %s destroys", FormatNumber(infra.TerraformPlans), FormatNumber(infra.TerraformApplies), FormatNumber(infra.TerraformDestroys))))
+
+		ratio := "no applies yet"
+		if infra.TerraformApplies > 0 {
+			ratio = fmt.Sprintf("%.1f plans per apply", infra.PlanApplyRatio)
+		}
+		verdict := "plans ahead"
+		if infra.TerraformApplies > 0 && infra.PlanApplyRatio < 1 {
+			verdict = "applies first, asks later"
+		}
+		lines = append(lines, AccentStyle.Render(">> "+ratio+": "+verdict))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderRemote(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	remote := stats.SSH
+
+	lines := []string{headerStyle.Render("-- REMOTE ") + SubtleStyle.Render(strings.Repeat("-", 63))}
+
+	maxCount := 0
+	if len(remote.TopHosts) > 0 {
+		maxCount = remote.TopHosts[0].Count
+	}
+	for i, host := range remote.TopHosts {
+		num := SubtleStyle.Render(fmt.Sprintf("%d.", i+1))
+		name := ValueStyle.Render(padRight(TruncateString(host.Command, 28), 28))
+		bar := ProgressBar(host.Count, maxCount, 24, ColorAccent)
+		count := LabelStyle.Render(fmt.Sprintf("%6s", FormatNumber(host.Count)))
+		lines = append(lines, fmt.Sprintf("%s %s %s %s", num, name, bar, count))
+	}
+
+	summary := AccentStyle.Render(fmt.Sprintf(">> %s to %s", FormatPlural(remote.Sessions, "login"), FormatPlural(remote.DistinctHosts, "host")))
+	if remote.Transfers > 0 {
+		summary += SubtleStyle.Render(fmt.Sprintf("  transfers: %s up, %s down", FormatNumber(remote.Uploads), FormatNumber(remote.Downloads)))
+	}
+	lines = append(lines, summary)
+
+	if stats.HasTimeData && remote.Sessions > 0 {
+		lines = append(lines, LabelStyle.Render("Login hours ")+Sparkline(remote.RemoteHours[:], ColorAccent)+
+			SubtleStyle.Render(fmt.Sprintf("  peak %02d:00", remote.PeakRemoteHour)))
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderPackages(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+	packages := stats.Packages
+
+	lines := []string{headerStyle.Render("-- PACKAGES ") + SubtleStyle.Render(strings.Repeat("-", 61))}
+
+	maxCount := 0
+	if len(packages.TopInstalled) > 0 {
+		maxCount = packages.TopInstalled[0].Count
+	}
+	for i, pkg := range packages.TopInstalled {
+		num := SubtleStyle.Render(fmt.Sprintf("%d.", i+1))
+		name := ValueStyle.Render(padRight(TruncateString(pkg.Package, 26), 26))
+		eco := LabelStyle.Render(padRight(pkg.Ecosystem, 9))
+		bar := ProgressBar(pkg.Count, maxCount, 18, ColorAccent)
+		count := LabelStyle.Render(fmt.Sprintf("%6s", FormatNumber(pkg.Count)))
+		lines = append(lines, fmt.Sprintf("%s %s %s %s %s", num, name, eco, bar, count))
+	}
+
+	var ecosystems []string
+	for _, eco := range packages.Ecosystems {
+		ecosystems = append(ecosystems, ValueStyle.Render(eco.Ecosystem)+LabelStyle.Render(fmt.Sprintf(" +%d -%d", eco.Installs, eco.Removes)))
+	}
+	if len(ecosystems) > 4 {
+		ecosystems = ecosystems[:4]
+	}
+	if len(ecosystems) > 0 {
+		lines = append(lines, strings.Join(ecosystems, SubtleStyle.Render("  |  ")))
+	}
+
+	lines = append(lines, AccentStyle.Render(fmt.Sprintf(">> %s, %s across %s",
+		FormatPlural(packages.Installs, "install"), FormatPlural(packages.Removes, "removal"), FormatPlural(len(packages.Ecosystems), "ecosystem"))))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderFlags(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- FLAGS ")+SubtleStyle.Render(strings.Repeat("-", 64)))
+
+	for _, tool := range stats.TopFlags {
+		var flags []string
+		width := 0
+		for _, flag := range tool.Flags {
+			item := fmt.Sprintf("%s x%s", flag.Command, FormatNumber(flag.Count))
+			if width+len(item) > 54 {
+				break
+			}
+			width += len(item) + 2
+			flags = append(flags, ValueStyle.Render(flag.Command)+LabelStyle.Render(" x"+FormatNumber(flag.Count)))
+		}
+		name := AccentStyle.Render(padRight(TruncateString(tool.Tool, 14), 14))
+		lines = append(lines, name+" "+strings.Join(flags, "  "))
+	}
+
+	if len(stats.FlagHeavy) > 0 {
+		top := stats.FlagHeavy[0]
+		hoarder := TruncateString(fmt.Sprintf(">> Flag hoarder: %s averages %.1f flags", top.Tool, top.AvgFlags), 72)
+		distinct := fmt.Sprintf("  (%s distinct flags overall)", FormatNumber(stats.DistinctFlags))
+		line := AccentStyle.Render(hoarder)
+		if len(hoarder)+len(distinct) <= 72 {
+			line += SubtleStyle.Render(distinct)
+		}
+		lines = append(lines, line)
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderHallOfFame(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	lines := []string{headerStyle.Render("-- ONE-LINER HALL OF FAME ") + SubtleStyle.Render(strings.Repeat("-", 47))}
+
+	for i, one := range stats.HallOfFame {
+		num := SubtleStyle.Render(fmt.Sprintf("%d.", i+1))
+		stages := AccentStyle.Render(fmt.Sprintf("%dx|", one.Stages))
+		lines = append(lines, fmt.Sprintf("%s %s %s", num, stages, ValueStyle.Render(TruncateString(one.Command, 62))))
+	}
+
+	var targets []string
+	width := 0
+	for _, target := range stats.PipeTargets {
+		item := fmt.Sprintf("%s x%s", target.Command, FormatNumber(target.Count))
+		if width+len(item) > 58 {
+			break
+		}
+		width += len(item) + 2
+		targets = append(targets, ValueStyle.Render(target.Command)+LabelStyle.Render(" x"+FormatNumber(target.Count)))
+	}
+	if len(targets) > 0 {
+		lines = append(lines, LabelStyle.Render("Piped into: ")+strings.Join(targets, "  "))
+	}
+
+	lines = append(lines, AccentStyle.Render(fmt.Sprintf(">> %.1f%% of commands pipe, %.1f stages on average", stats.PipePct, stats.AvgPipeDepth))+
+		SubtleStyle.Render(fmt.Sprintf("  (record: %d)", stats.MaxPipeDepth)))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+func renderAliasSuggestions(stats *analyzer.Stats) string {
+	style := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDim).
+		Padding(0, 1).
+		Width(TotalWidth)
+
+	headerStyle := lipgloss.NewStyle().Foreground(ColorSecondary).Bold(true)
+
+	var lines []string
+	lines = append(lines, headerStyle.Render("-- ALIAS SUGGESTIONS ")+SubtleStyle.Render(strings.Repeat("-", 52)))
+
+	// show top 5
+	for i, s := range stats.AliasSuggestions {
+		if i >= 5 {
+			break
+		}
+		name := AccentStyle.Render(padRight(s.Name, 6))
+		command := ValueStyle.Render(padRight(TruncateString(s.Command, 38), 38))
+		saved := LabelStyle.Render(fmt.Sprintf("x%-5s -%s keys", FormatNumber(s.Count), FormatNumber(s.Saved)))
+		lines = append(lines, fmt.Sprintf("%s = %s %s", name, command, saved))
+	}
+	lines = append(lines, SubtleStyle.Render(">> terminal-wrapped -aliases zsh > ~/.wrapped_aliases"))
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// formatWorkflow joins workflow steps with arrows
+func FormatWorkflow(steps []string) string {
+	return strings.Join(steps, " -> ")
+}
+
+// formatEditorWars shows the top two editors' share of editor launches
+func formatEditorWars(split []analyzer.CommandCount) string {
+	total := 0
+	for _, editor := range split {
+		total += editor.Count
+	}
+	return fmt.Sprintf("%s %.0f%% / %s %.0f%%",
+		split[0].Command, float64(split[0].Count)/float64(total)*100,
+		split[1].Command, float64(split[1].Count)/float64(total)*100)
+}
+
+// formatCounts lists up to n counts as "a x3, b x1"
+func formatCounts(counts []analyzer.CommandCount, n int) string {
+	var parts []string
+	for i, c := range counts {
+		if i == n {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s x%s", c.Command, FormatNumber(c.Count)))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,251 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+const (
+	storyFrameRate     = 30 * time.Millisecond
+	storyAnimationTime = 1200 * time.Millisecond
+	storyCardWidth     = 56
+)
+
+// storyCard renders one slide; progress runs from 0 to 1 while the card animates in
+type storyCard func(progress float64) string
+
+// runStory shows the wrap as a sequence of full-screen cards with keyboard navigation.
+// when stdin/stdout are not a terminal it prints the static report instead
+func RunStory(stats *analyzer.Stats, archetype *analyzer.Archetype) error {
+	if !IsInteractive() {
+		fmt.Print(Render(stats, archetype))
+		return nil
+	}
+
+	cards := storyCards(stats, archetype)
+
+	scr, err := openScreen()
+	if err != nil {
+		return err
+	}
+
+	keys := scr.keys()
+	ticker := time.NewTicker(storyFrameRate)
+	defer ticker.Stop()
+
+	current := 0
+	shownAt := time.Now()
+	finished := false
+
+	for {
+		progress := float64(time.Since(shownAt)) / float64(storyAnimationTime)
+		width, height := scr.size()
+		scr.draw(storyFrame(cards[current](math.Min(progress, 1)), current, len(cards), width, height))
+
+		select {
+		case key, ok := <-keys:
+			if !ok {
+				scr.close()
+				return nil
+			}
+			switch key {
+			case "right", "l", " ", "enter":
+				if current == len(cards)-1 {
+					finished = true
+				} else {
+					current++
+					shownAt = time.Now()
+				}
+			case "left", "h", "backspace":
+				if current > 0 {
+					current--
+					shownAt = time.Now()
+				}
+			case "q", "esc", "ctrl+c":
+				scr.close()
+				return nil
+			}
+		case <-ticker.C:
+		}
+
+		if finished {
+			scr.close()
+			// leave the full report behind once the story is over
+			fmt.Print(Render(stats, archetype))
+			return nil
+		}
+	}
+}
+
+// storyFrame places a card in the middle of the screen with a progress indicator
+func storyFrame(card string, current, total, width, height int) string {
+	box := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPurple).
+		Padding(1, 2).
+		Width(storyCardWidth).
+		Align(lipgloss.Center).
+		Render(card)
+
+	var dots strings.Builder
+	for i := 0; i < total; i++ {
+		if i == current {
+			dots.WriteString(AccentStyle.Render("●"))
+		} else {
+			dots.WriteString(SubtleStyle.Render("○"))
+		}
+		if i < total-1 {
+			dots.WriteString(" ")
+		}
+	}
+
+	help := SubtleStyle.Render("←/→ navigate · space next · q quit")
+	return placeCenter(box+"\n\n"+dots.String()+"\n"+help, width, height)
+}
+
+// storyCards builds the slides, skipping time-based ones when there are no timestamps
+func storyCards(stats *analyzer.Stats, archetype *analyzer.Archetype) []storyCard {
+	cards := []storyCard{storyTotalCard(stats)}
+
+	if len(stats.TopCommands) > 0 {
+		cards = append(cards, storyTopCommandCard(stats))
+	}
+	if stats.HasTimeData && !stats.BusiestDay.IsZero() {
+		cards = append(cards, storyBusiestDayCard(stats))
+		cards = append(cards, storyNightOwlCard(stats))
+	}
+
+	cards = append(cards, storyArchetypeCard(archetype))
+	return cards
+}
+
+func storyTotalCard(stats *analyzer.Stats) storyCard {
+	return func(p float64) string {
+		lines := []string{
+			LabelStyle.Render("All in all you typed"),
+			"",
+			storyBigNumber(FormatNumber(countUp(stats.TotalCommands, p))),
+			"",
+			LabelStyle.Render("commands into your terminal"),
+		}
+		if stats.HasTimeData && p >= 1 {
+			lines = append(lines, "", SubtleStyle.Render(fmt.Sprintf("that's ~%.0f a day", stats.CommandsPerDay)))
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func storyTopCommandCard(stats *analyzer.Stats) storyCard {
+	top := stats.TopCommands[0]
+	pct := float64(top.Count) / float64(max(stats.TotalCommands, 1)) * 100
+
+	return func(p float64) string {
+		name := lipgloss.NewStyle().Bold(true).Foreground(getCmdColor(top.Command)).Render(typewriter(top.Command, p*2))
+		lines := []string{
+			LabelStyle.Render("Your #1 command was"),
+			"",
+			name,
+			"",
+			ValueStyle.Render(fmt.Sprintf("%s times", FormatNumber(countUp(top.Count, p)))),
+			SubtleStyle.Render(fmt.Sprintf("%.1f%% of everything you ran", pct*easeOut(p))),
+		}
+
+		// runners-up slide in one by one
+		if len(stats.TopCommands) > 1 {
+			lines = append(lines, "")
+			for i, cmd := range stats.TopCommands[1:min(len(stats.TopCommands), 5)] {
+				if p < 0.5+float64(i)*0.12 {
+					break
+				}
+				lines = append(lines, LabelStyle.Render(fmt.Sprintf("%d. %-10s %s", i+2, cmd.Command, FormatNumber(cmd.Count))))
+			}
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func storyBusiestDayCard(stats *analyzer.Stats) storyCard {
+	return func(p float64) string {
+		return strings.Join([]string{
+			LabelStyle.Render("Your busiest day was"),
+			"",
+			storyBigNumber(typewriter(stats.BusiestDay.Format("Monday, Jan 2"), p*1.5)),
+			"",
+			ValueStyle.Render(fmt.Sprintf("%s commands in a single day", FormatNumber(countUp(stats.BusiestDayCount, p)))),
+			SubtleStyle.Render(fmt.Sprintf("longest streak: %d days", stats.LongestStreak)),
+		}, "\n")
+	}
+}
+
+func storyNightOwlCard(stats *analyzer.Stats) storyCard {
+	verdict := "You mostly sleep at night. Respect."
+	switch {
+	case stats.NightOwlPct > 15:
+		verdict = "Certified night owl."
+	case stats.NightOwlPct > 5:
+		verdict = "The terminal glows after dark."
+	}
+
+	return func(p float64) string {
+		lines := []string{
+			LabelStyle.Render("After midnight you ran"),
+			"",
+			storyBigNumber(fmt.Sprintf("%.0f%%", stats.NightOwlPct*easeOut(p))),
+			"",
+			LabelStyle.Render("of your commands"),
+		}
+		if p >= 1 {
+			lines = append(lines, "",
+				ValueStyle.Render(verdict),
+				SubtleStyle.Render(fmt.Sprintf("peak hour: %s %02d:00", analyzer.GetDayName(stats.PeakDay), stats.PeakHour)))
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func storyArchetypeCard(archetype *analyzer.Archetype) storyCard {
+	return func(p float64) string {
+		lines := []string{LabelStyle.Render("Your archetype is..."), ""}
+
+		// hold the reveal for a beat, then type it out
+		reveal := (p - 0.3) / 0.5
+		if reveal > 0 {
+			title := lipgloss.NewStyle().Bold(true).Foreground(ColorBright).Render(typewriter(archetype.Name, reveal))
+			lines = append(lines, AccentStyle.Render(archetype.Icon)+"  "+title)
+		} else {
+			lines = append(lines, SubtleStyle.Render(strings.Repeat(".", int(p*10)%4)))
+		}
+		lines = append(lines, "")
+		if p >= 1 {
+			lines = append(lines, lipgloss.NewStyle().Foreground(ColorMuted).Italic(true).Render("\""+archetype.Tagline+"\""))
+		}
+		return strings.Join(lines, "\n")
+	}
+}
+
+func storyBigNumber(s string) string {
+	return lipgloss.NewStyle().Bold(true).Foreground(ColorAccent).Render(s)
+}
+
+// countUp animates an integer from zero to its final value
+func countUp(n int, p float64) int {
+	return int(math.Round(float64(n) * easeOut(p)))
+}
+
+// typewriter reveals a string left to right
+func typewriter(s string, p float64) string {
+	runes := []rune(s)
+	n := int(math.Ceil(float64(len(runes)) * math.Min(math.Max(p, 0), 1)))
+	return string(runes[:n])
+}
+
+func easeOut(p float64) float64 {
+	p = math.Min(math.Max(p, 0), 1)
+	return 1 - math.Pow(1-p, 3)
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
+)
+
+var storyArchetype = &analyzer.Archetype{Name: "The Shipper", Icon: ">>", Tagline: "ship it"}
+
+func TestStoryCards(t *testing.T) {
+	top := []analyzer.CommandCount{{Command: "git", Count: 40}, {Command: "ls", Count: 10}}
+	busiest := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		stats *analyzer.Stats
+		want  []string // headline of every card, in order
+	}{
+		{
+			"empty stats",
+			&analyzer.Stats{},
+			[]string{"All in all you typed", "Your archetype is..."},
+		},
+		{
+			"no timestamps",
+			&analyzer.Stats{TotalCommands: 50, TopCommands: top},
+			[]string{"All in all you typed", "Your #1 command was", "Your archetype is..."},
+		},
+		{
+			"timestamps without a busiest day",
+			&analyzer.Stats{TotalCommands: 50, TopCommands: top, HasTimeData: true},
+			[]string{"All in all you typed", "Your #1 command was", "Your archetype is..."},
+		},
+		{
+			"everything",
+			&analyzer.Stats{TotalCommands: 50, TopCommands: top, HasTimeData: true, BusiestDay: busiest},
+			[]string{"All in all you typed", "Your #1 command was", "Your busiest day was", "After midnight you ran", "Your archetype is..."},
+		},
+	}
+	for _, tt := range tests {
+		cards := storyCards(tt.stats, storyArchetype)
+		if len(cards) != len(tt.want) {
+			t.Errorf("%s: got %d cards, want %d", tt.name, len(cards), len(tt.want))
+			continue
+		}
+		for i, card := range cards {
+			// every card must render at both ends of its animation
+			card(0)
+			if got := strings.SplitN(card(1), "\n", 2)[0]; strings.TrimSpace(got) != tt.want[i] {
+				t.Errorf("%s: card %d starts with %q, want %q", tt.name, i, got, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestStoryCardsAnimate(t *testing.T) {
+	stats := &analyzer.Stats{
+		TotalCommands: 1234,
+		TopCommands:   []analyzer.CommandCount{{Command: "git", Count: 600}},
+	}
+	cards := storyCards(stats, storyArchetype)
+
+	total := cards[0]
+	if strings.Contains(total(0), "1,234") || !strings.Contains(total(1), "1,234") {
+		t.Errorf("total card doesn't count up to 1,234:\n%s\n---\n%s", total(0), total(1))
+	}
+
+	arch := cards[len(cards)-1]
+	if strings.Contains(arch(0), storyArchetype.Name) {
+		t.Errorf("archetype revealed at the start:\n%s", arch(0))
+	}
+	if got := arch(1); !strings.Contains(got, storyArchetype.Name) || !strings.Contains(got, storyArchetype.Tagline) {
+		t.Errorf("archetype not revealed at the end:\n%s", got)
+	}
+}
+
+func TestStoryFrame(t *testing.T) {
+	frame := storyFrame("hello", 1, 3, 80, 30)
+	lines := strings.Split(frame, "\n")
+	if !strings.Contains(frame, "hello") {
+		t.Fatalf("card missing from frame:\n%s", frame)
+	}
+	if !strings.Contains(frame, "○ ● ○") {
+		t.Errorf("progress dots don't mark card 2 of 3:\n%s", frame)
+	}
+	// vertically centered: blank lines above the box
+	if strings.TrimSpace(lines[0]) != "" {
+		t.Errorf("frame not centered, first line %q", lines[0])
+	}
+
+	// a screen smaller than the card still renders it
+	if small := storyFrame("hello", 0, 1, 10, 2); !strings.Contains(small, "hello") || !strings.HasPrefix(strings.TrimSpace(small), "╭") {
+		t.Errorf("small screen frame:\n%s", small)
+	}
+}
+
+func TestEaseOut(t *testing.T) {
+	tests := []struct {
+		p    float64
+		want float64
+	}{
+		{-1, 0},
+		{0, 0},
+		{0.5, 0.875},
+		{1, 1},
+		{2, 1},
+	}
+	for _, tt := range tests {
+		if got := easeOut(tt.p); got != tt.want {
+			t.Errorf("easeOut(%v) = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestCountUp(t *testing.T) {
+	tests := []struct {
+		n    int
+		p    float64
+		want int
+	}{
+		{100, 0, 0},
+		{100, 0.5, 88},
+		{100, 1, 100},
+		{100, 1.5, 100},
+		{0, 1, 0},
+		{1, 0.1, 0},
+		{1, 1, 1},
+	}
+	for _, tt := range tests {
+		if got := countUp(tt.n, tt.p); got != tt.want {
+			t.Errorf("countUp(%d, %v) = %d, want %d", tt.n, tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestTypewriter(t *testing.T) {
+	tests := []struct {
+		s    string
+		p    float64
+		want string
+	}{
+		{"hello", -0.5, ""},
+		{"hello", 0, ""},
+		{"hello", 0.01, "h"},
+		{"hello", 0.5, "hel"},
+		{"hello", 1, "hello"},
+		{"hello", 3, "hello"},
+		{"", 1, ""},
+		{"日本語", 0.5, "日本"},
+	}
+	for _, tt := range tests {
+		if got := typewriter(tt.s, tt.p); got != tt.want {
+			t.Errorf("typewriter(%q, %v) = %q, want %q", tt.s, tt.p, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+// ANSI control sequences used by the interactive screens
+const (
+	ansiAltScreenOn  = "\x1b[?1049h"
+	ansiAltScreenOff = "\x1b[?1049l"
+	ansiHideCursor   = "\x1b[?25l"
+	ansiShowCursor   = "\x1b[?25h"
+	ansiHome         = "\x1b[H"
+	ansiClearLine    = "\x1b[K"
+	ansiClearBelow   = "\x1b[J"
+)
+
+// IsInteractive reports whether both stdin and stdout are attached to a terminal
+func IsInteractive() bool {
+	return term.IsTerminal(os.Stdin.Fd()) && term.IsTerminal(os.Stdout.Fd())
+}
+
+// screen is a full-screen raw-mode session on the alternate buffer
+type screen struct {
+	in    *os.File
+	out   *os.File
+	state *term.State
+}
+
+// openScreen switches the terminal to raw mode and the alternate screen
+func openScreen() (*screen, error) {
+	state, err := term.MakeRaw(os.Stdin.Fd())
+	if err != nil {
+		return nil, err
+	}
+	s := &screen{in: os.Stdin, out: os.Stdout, state: state}
+	io.WriteString(s.out, ansiAltScreenOn+ansiHideCursor)
+	return s, nil
+}
+
+// close restores the terminal to its original state
+func (s *screen) close() {
+	io.WriteString(s.out, ansiShowCursor+ansiAltScreenOff)
+	term.Restore(s.in.Fd(), s.state)
+}
+
+// size returns the terminal size, falling back to 80x24
+func (s *screen) size() (width, height int) {
+	width, height, err := term.GetSize(s.out.Fd())
+	if err != nil || width <= 0 || height <= 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// draw repaints the screen without clearing it first to avoid flicker.
+// raw mode disables output post-processing, so lines need explicit \r
+func (s *screen) draw(content string) {
+	var sb strings.Builder
+	sb.WriteString(ansiHome)
+	for i, line := range strings.Split(content, "\n") {
+		if i > 0 {
+			sb.WriteString("\r\n")
+		}
+		sb.WriteString(line)
+		sb.WriteString(ansiClearLine)
+	}
+	sb.WriteString(ansiClearBelow)
+	io.WriteString(s.out, sb.String())
+}
+
+// keys decodes raw stdin bytes into key names ("left", "enter", "q", ...)
+func (s *screen) keys() <-chan string {
+	ch := make(chan string)
+	go func() {
+		buf := make([]byte, 64)
+		for {
+			n, err := s.in.Read(buf)
+			if err != nil {
+				close(ch)
+				return
+			}
+			for _, key := range decodeKeys(buf[:n]) {
+				ch <- key
+			}
+		}
+	}()
+	return ch
+}
+
+var csiKeys = map[string]string{
+	"A": "up", "B": "down", "C": "right", "D": "left",
+	"H": "home", "F": "end", "5~": "pgup", "6~": "pgdown",
+}
+
+func decodeKeys(b []byte) []string {
+	var keys []string
+	for i := 0; i < len(b); i++ {
+		switch c := b[i]; {
+		case c == 0x1b && i+1 < len(b) && (b[i+1] == '[' || b[i+1] == 'O'):
+			// CSI sequence: parameter bytes followed by a final byte
+			j := i + 2
+			for j < len(b) && (b[j] >= '0' && b[j] <= '9' || b[j] == ';') {
+				j++
+			}
+			if j < len(b) {
+				if key := csiKeys[string(b[i+2:j])+string(b[j])]; key != "" {
+					keys = append(keys, key)
+				}
+			}
+			i = j
+		case c == 0x1b:
+			keys = append(keys, "esc")
+		case c == 0x03:
+			keys = append(keys, "ctrl+c")
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+		case c == '\t':
+			keys = append(keys, "tab")
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, "backspace")
+		case c >= 0x20 && c < 0x7f:
+			keys = append(keys, string(rune(c)))
+		}
+	}
+	return keys
+}
+
+// placeCenter centers a block of text in a width x height area
+func placeCenter(content string, width, height int) string {
+	lines := strings.Split(content, "\n")
+	top := max((height-len(lines))/2, 0)
+
+	var sb strings.Builder
+	sb.WriteString(strings.Repeat("\n", top))
+	for i, line := range lines {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(CenterText(line, width))
+	}
+	return sb.String()
+}
@@ -0,0 +1,8 @@
+# terminal-wrapped alias suggestions
+# source this file from your bash config, or copy the ones you like
+
+# repeated long commands
+# used 6 times, saves ~72 keystrokes
+alias vh='vim handler.go'
+# used 9 times, saves ~63 keystrokes
+alias gst='git status'
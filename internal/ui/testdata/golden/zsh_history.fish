@@ -0,0 +1,20 @@
+# terminal-wrapped alias suggestions
+# source this file from your fish config, or copy the ones you like
+
+# repeated long commands
+# used 27 times, saves ~297 keystrokes
+alias gt 'go test ./...'
+# used 41 times, saves ~287 keystrokes
+alias gst 'git status'
+# used 27 times, saves ~270 keystrokes
+alias cco 'cd ~/code/api'
+# used 8 times, saves ~208 keystrokes
+alias sd 'ssh deploy@build.example.com'
+# used 8 times, saves ~200 keystrokes
+alias klfan 'kubectl logs -f api -n staging'
+# used 18 times, saves ~180 keystrokes
+alias gcm 'git commit -m'
+# used 15 times, saves ~180 keystrokes
+alias vh 'vim handler.go'
+# used 8 times, saves ~176 keystrokes
+alias kgpns 'kubectl get pods -n staging'
@@ -1,8 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/Anish-Reddy-K/terminal-wrapped/internal/analyzer"
 	"github.com/Anish-Reddy-K/terminal-wrapped/internal/parser"
@@ -10,37 +15,315 @@ import (
 )
 
 func main() {
+	htmlPath := flag.String("html", "", "write a self-contained HTML report to `file`")
+	imagePath := flag.String("image", "", "write a share card to `file` (.png or .svg)")
+	story := flag.Bool("story", false, "reveal the wrap as an interactive slideshow")
+	explore := flag.Bool("explore", false, "browse your history interactively")
+	dotPath := flag.String("dot", "", "write the command transition graph to `file` (Graphviz DOT)")
+	aliasShell := flag.String("aliases", "", "print suggested aliases as a snippet for `shell` (zsh, bash or fish)")
+	noAliases := flag.Bool("no-aliases", false, "don't resolve aliases from shell config files")
+	auditFormat := flag.String("audit", "", "print a dangerous command audit as `format` (text or json)")
+	packageFormat := flag.String("packages", "", "print the package install/remove ledger as `format` (csv or json)")
+	riskRules := flag.String("risk-rules", "", "extra audit rules from a JSON `file`")
+	hashHosts := flag.Bool("hash-hosts", false, "replace remote hostnames with short digests in every output, for sharing")
+	sessionGap := flag.Duration("session-gap", analyzer.DefaultSessionGap, "idle `duration` that ends a work session")
+	tz := flag.String("tz", "", "IANA time `zone` for the report, e.g. Europe/Berlin (default: local zone)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage: terminal-wrapped [flags] [history-file[@Zone] ...]\n\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	// -explore, -story and the stdout exports each take over the terminal, so
+	// only one can run. file outputs combine with each other and with them
+	var modes []string
+	for _, mode := range []struct {
+		name string
+		set  bool
+	}{
+		{"-explore", *explore},
+		{"-story", *story},
+		{"-audit", *auditFormat != ""},
+		{"-packages", *packageFormat != ""},
+		{"-aliases", *aliasShell != ""},
+	} {
+		if mode.set {
+			modes = append(modes, mode.name)
+		}
+	}
+	if len(modes) > 1 {
+		fmt.Fprintf(os.Stderr, "Error: %s can't be combined\n", strings.Join(modes, " and "))
+		os.Exit(1)
+	}
+	fileOutput := *htmlPath != "" || *dotPath != "" || *imagePath != ""
+	if *explore && fileOutput {
+		fmt.Fprintf(os.Stderr, "Error: -explore can't be combined with -html, -dot or -image\n")
+		os.Exit(1)
+	}
+	// keep stdout clean for exports when files are written too
+	status := os.Stdout
+	if *auditFormat != "" || *packageFormat != "" || *aliasShell != "" {
+		status = os.Stderr
+	}
+
+	// zone for timestamps of sources without their own @Zone
+	location := time.Local
+	if *tz != "" {
+		loc, err := time.LoadLocation(*tz)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: unknown timezone %q\n", *tz)
+			os.Exit(1)
+		}
+		location = loc
+	}
+
 	// auto-detect shell
 	shell := parser.DetectShell()
 
-	// get history file path
-	historyPath := parser.GetHistoryPath(shell)
+	// history files from the command line, or the shell's default one
+	var sources []parser.Source
+	if flag.NArg() == 0 {
+		sources = append(sources, parser.Source{Path: parser.GetHistoryPath(shell), Shell: shell})
+	}
+	for _, arg := range flag.Args() {
+		src, err := parser.ParseSource(arg, shell)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		sources = append(sources, src)
+	}
 
-	// check if history file exists
-	if _, err := os.Stat(historyPath); os.IsNotExist(err) {
-		fmt.Fprintf(os.Stderr, "Error: History file not found: %s\n", historyPath)
-		fmt.Fprintf(os.Stderr, "Make sure you're using zsh or bash.\n")
-		os.Exit(1)
+	// check if history files exist
+	for _, src := range sources {
+		if _, err := os.Stat(src.Path); os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "Error: History file not found: %s\n", src.Path)
+			fmt.Fprintf(os.Stderr, "Make sure you're using zsh or bash.\n")
+			os.Exit(1)
+		}
 	}
 
-	// parse history
-	data, err := parser.Parse(historyPath, shell)
+	// resolve aliases (g -> git) so they count as the real command
+	var aliases parser.Aliases
+	if !*noAliases {
+		home, _ := os.UserHomeDir()
+		aliases = parser.LoadAliases(home)
+	}
+
+	// interactive explorer works on the raw history
+	if *explore {
+		data, err := loadHistory(sources, location)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error parsing history: %v\n", err)
+			os.Exit(1)
+		}
+		if len(data.Commands) == 0 {
+			fmt.Fprintf(os.Stderr, "No commands found in history file: %s\n", data.FilePath)
+			os.Exit(1)
+		}
+		data.ExpandAliases(aliases)
+		if err := ui.RunExplorer(data); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running explorer: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// analyze
+	opts := analyzer.DefaultOptions()
+	opts.SessionGap = *sessionGap
+	opts.HashHosts = *hashHosts
+	opts.NameInUse = nameInUse()
+	// only the package export needs every install event
+	opts.SkipLedger = *packageFormat == ""
+	if *riskRules != "" {
+		rules, err := analyzer.LoadRiskRules(*riskRules, opts.RiskRules)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error loading risk rules: %v\n", err)
+			os.Exit(1)
+		}
+		opts.RiskRules = rules
+	}
+	stats, err := analyzeSources(sources, location, aliases, opts)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error parsing history: %v\n", err)
 		os.Exit(1)
 	}
-
-	if len(data.Commands) == 0 {
-		fmt.Fprintf(os.Stderr, "No commands found in history file: %s\n", historyPath)
+	if stats.TotalCommands == 0 {
+		paths := make([]string, len(sources))
+		for i, src := range sources {
+			paths[i] = src.Path
+		}
+		fmt.Fprintf(os.Stderr, "No commands found in history file: %s\n", strings.Join(paths, ", "))
 		os.Exit(1)
 	}
 
-	// analyze
-	stats := analyzer.Analyze(data)
-
 	// detect archetype
 	archetype := analyzer.DetectArchetype(stats)
 
-	// render output
-	fmt.Print(ui.Render(stats, archetype))
+	// html report
+	if *htmlPath != "" {
+		report, err := ui.RenderHTML(stats, archetype)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error rendering HTML report: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(*htmlPath, []byte(report), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing HTML report: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(status, "HTML report written to %s\n", *htmlPath)
+	}
+
+	// workflow graph
+	if *dotPath != "" {
+		if err := os.WriteFile(*dotPath, []byte(ui.RenderDOT(stats)), 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing transition graph: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(status, "Transition graph written to %s (render with: dot -Tpng %s -o workflows.png)\n", *dotPath, *dotPath)
+	}
+
+	// share card
+	if *imagePath != "" {
+		if err := writeCard(*imagePath, stats, archetype); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing share card: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(status, "Share card written to %s\n", *imagePath)
+	}
+
+	// dangerous command audit
+	if *auditFormat != "" {
+		report, err := ui.RenderAudit(stats, *auditFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(report)
+		return
+	}
+
+	// package ledger
+	if *packageFormat != "" {
+		ledger, err := ui.RenderPackageLedger(stats, *packageFormat)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(ledger)
+		return
+	}
+
+	// alias snippet
+	if *aliasShell != "" {
+		snippet, err := ui.RenderAliasSnippet(stats, *aliasShell)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Print(snippet)
+		return
+	}
+
+	// interactive story mode
+	if *story {
+		if err := ui.RunStory(stats, archetype); err != nil {
+			fmt.Fprintf(os.Stderr, "Error running story mode: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// render output, unless only files were asked for
+	if !fileOutput {
+		fmt.Print(ui.Render(stats, archetype))
+	}
+}
+
+// analyzeSources streams every history file through the analyzer, interleaved
+// chronologically, so even huge histories are never held in memory
+func analyzeSources(sources []parser.Source, location *time.Location, aliases parser.Aliases, opts analyzer.Options) (*analyzer.Stats, error) {
+	streams := make([]parser.CommandStream, 0, len(sources))
+	for _, src := range sources {
+		file, err := os.Open(src.Path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		scanner := parser.NewScanner(file, src.Shell)
+		if src.Location != nil {
+			scanner.SetLocation(src.Location)
+		} else {
+			scanner.SetLocation(location)
+		}
+		streams = append(streams, scanner)
+	}
+
+	an := analyzer.NewAnalyzer(opts, time.Now().In(location))
+	stream := parser.MergeStreams(streams...)
+	for stream.Scan() {
+		cmd := stream.Command()
+		aliases.Expand(cmd)
+		an.Add(cmd)
+	}
+	return an.Finish(), stream.Err()
+}
+
+// loadHistory parses every history file into memory and merges them, for
+// the explorer which needs random access
+func loadHistory(sources []parser.Source, location *time.Location) (*parser.HistoryData, error) {
+	var histories []*parser.HistoryData
+	for _, src := range sources {
+		history, err := parser.Parse(src.Path, src.Shell)
+		if err != nil {
+			return nil, err
+		}
+		if src.Location != nil {
+			history.SetLocation(src.Location)
+		} else {
+			history.SetLocation(location)
+		}
+		histories = append(histories, history)
+	}
+	data := parser.Merge(histories...)
+	data.Location = location
+	return data, nil
+}
+
+// writeCard renders the share card in the format implied by the file extension
+func writeCard(path string, stats *analyzer.Stats, archetype *analyzer.Archetype) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".svg":
+		return os.WriteFile(path, []byte(ui.RenderCardSVG(stats, archetype)), 0644)
+	case ".png":
+		file, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		if err := ui.RenderCardPNG(file, stats, archetype); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	default:
+		return fmt.Errorf("unsupported image format %q (use .png or .svg)", filepath.Ext(path))
+	}
+}
+
+// nameInUse reports whether the shell already resolves a name, as one of the
+// user's aliases or functions or a command on PATH. suggested aliases must
+// not shadow any of them, even with -no-aliases
+func nameInUse() func(name string) bool {
+	home, _ := os.UserHomeDir()
+	aliases := parser.LoadAliases(home)
+	functions := parser.LoadFunctions(home)
+	return func(name string) bool {
+		if _, ok := aliases[name]; ok || functions[name] {
+			return true
+		}
+		_, err := exec.LookPath(name)
+		return err == nil
+	}
 }